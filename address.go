@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// Address is a parsed host:port value
+type Address struct {
+	Host string
+	Port uint16
+}
+
+// ParseAddress parses host:port value (the host may be empty, e.g. ":8080")
+func ParseAddress(str string) (addr Address, err error) {
+	var port string
+	addr.Host, port, err = net.SplitHostPort(str)
+	if err != nil {
+		return
+	}
+
+	var ui64 uint64
+	ui64, err = strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		err = fmt.Errorf("can't parse port `%s` of address `%s`", port, str)
+
+		return
+	}
+
+	addr.Port = uint16(ui64)
+
+	return
+}
+
+func (a Address) String() string {
+	return net.JoinHostPort(a.Host, strconv.FormatUint(uint64(a.Port), 10))
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"eb":  1000 * 1000 * 1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+	"eib": 1 << 60,
+}
+
+// parseBytes parses a number of bytes with optional SI ("64MB") or IEC ("1GiB") suffix
+func parseBytes(str string) (size uint64, ok bool) {
+	str = strings.TrimSpace(str)
+
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(str)
+	}
+
+	num, suffix := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+	if num == "" {
+		return
+	}
+
+	unit, found := byteUnits[suffix]
+	if !found {
+		return
+	}
+
+	r, valid := new(big.Rat).SetString(num)
+	if !valid {
+		return
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(unit)))
+	if !r.IsInt() || r.Num().Cmp(new(big.Int).SetUint64(math.MaxUint64)) > 0 {
+		return
+	}
+
+	return r.Num().Uint64(), true
+}
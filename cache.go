@@ -0,0 +1,181 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+var stale uint32
+
+// Cache sets file to keep the last accepted configuration of the remote source (InitURL or InitKV),
+// the configuration is loaded from the file if the source is unreachable on initialization.
+// The file keeps configuration as it's got from the source, so encrypted values and secret references aren't resolved in it.
+func Cache(cachePath string) InitOption {
+	return func(o *initOptions) {
+		o.cache = cachePath
+	}
+}
+
+// IsStale returns true if the configuration is loaded from the cache because the remote source is unreachable,
+// it's false again after the first successful load from the source
+func IsStale() bool {
+	return atomic.LoadUint32(&stale) == 1
+}
+
+type cacheFile struct {
+	Source   string          `json:"source"`
+	Time     time.Time       `json:"time"`
+	Checksum string          `json:"checksum"`
+	Object   json.RawMessage `json:"object"`
+}
+
+// accept marks the configuration loaded from the source as fresh and keeps it in the cache
+func accept(cachePath, src string, raw []byte) {
+	atomic.StoreUint32(&stale, 0)
+
+	if cachePath == "" {
+		return
+	}
+
+	err := writeCache(cachePath, src, raw)
+	if err != nil {
+		logger.Load().(logFn).error(err.Error(),
+			slog.String("cache", cachePath),
+			slog.String("error_type", fmt.Sprintf("%T", err)))
+
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Configuration is cached to %s", cachePath), slog.String("cache", cachePath))
+}
+
+// fallback loads the configuration from the cache instead of the unreachable source,
+// the cache written for other source is ignored
+func fallback(cachePath, src string, cause error) (err error) {
+	if cachePath == "" {
+		return cause
+	}
+
+	defer notifyCommitted()
+
+	var cached cacheFile
+	cached, err = readCache(cachePath)
+	if err == nil && cached.Source != src {
+		err = fmt.Errorf("cache %s keeps config of other source %s", cachePath, cached.Source)
+	}
+	if err != nil {
+		logger.Load().(logFn).warn(fmt.Sprintf("Configuration can't be loaded from cache: %s", err.Error()),
+			slog.String("cache", cachePath))
+
+		return cause
+	}
+
+	var obj Object
+	obj, err = decodeJson(cached.Object)
+	if err != nil {
+		err = fmt.Errorf("can't load config from cache %s because: %s", cachePath, err.Error())
+
+		return
+	}
+
+	var sensitive, derived []string
+	obj, sensitive, derived, err = prepare(obj)
+	if err != nil {
+		err = fmt.Errorf("can't load config from cache %s because: %s", cachePath, err.Error())
+
+		return
+	}
+
+	prev := atomic.SwapUint32(&stale, 1)
+
+	var gen uint64
+	gen, err = commit(obj, src, sensitive, derived)
+	if err != nil {
+		atomic.StoreUint32(&stale, prev)
+
+		err = fmt.Errorf("can't load config from cache %s because: %s", cachePath, err.Error())
+
+		return
+	}
+
+	logger.Load().(logFn).warn(fmt.Sprintf("Configuration is loaded from cache %s because: %s", cachePath, cause.Error()),
+		slog.String("cache", cachePath),
+		slog.String("source", src),
+		slog.Time("cached_at", cached.Time),
+		slog.Uint64("generation", gen))
+
+	atomic.StoreUint32(&isLoaded, 1)
+
+	return
+}
+
+// writeCache writes the configuration with its checksum to temporary file and renames it to the cache file
+func writeCache(cachePath, src string, raw []byte) (err error) {
+	buf := &bytes.Buffer{}
+	err = json.Compact(buf, raw)
+	if err != nil {
+		err = fmt.Errorf("can't write cache %s because: %s", cachePath, err.Error())
+
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+
+	// HTML characters aren't escaped to keep the object as it's checksummed
+	out := &bytes.Buffer{}
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(cacheFile{
+		Source:   src,
+		Time:     time.Now(),
+		Checksum: hex.EncodeToString(sum[:]),
+		Object:   buf.Bytes(),
+	})
+	if err != nil {
+		err = fmt.Errorf("can't write cache %s because: %s", cachePath, err.Error())
+
+		return
+	}
+
+	err = writeFile(cachePath, out.Bytes(), 0600)
+	if err != nil {
+		err = fmt.Errorf("can't write cache %s because: %s", cachePath, err.Error())
+	}
+
+	return
+}
+
+// readCache reads the cache file and checks its checksum
+func readCache(cachePath string) (cached cacheFile, err error) {
+	var bs []byte
+	bs, err = os.ReadFile(cachePath)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(bs, &cached)
+	if err != nil {
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	err = json.Compact(buf, cached.Object)
+	if err != nil {
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(sum[:]) != cached.Checksum {
+		err = fmt.Errorf("checksum of cache %s mismatches", cachePath)
+	}
+
+	return
+}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		version int64 = 1
+		down    uint32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadUint32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		fmt.Fprintf(w, `{"version": %d, "dsn": "${env:CONFIG_CACHE_DSN}"}`, atomic.LoadInt64(&version))
+	}))
+	defer server.Close()
+
+	os.Setenv("CONFIG_CACHE_DSN", "postgres://localhost")
+	defer os.Unsetenv("CONFIG_CACHE_DSN")
+
+	cachePath := filepath.Join(dir, "config.cache")
+	options := []InitOption{NoPolling(), Cache(cachePath), Retry(1, time.Millisecond)}
+
+	err = InitURL(server.URL, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if IsStale() {
+		t.Error("Configuration from source is stale")
+	}
+
+	bs, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bs), "${env:CONFIG_CACHE_DSN}") {
+		t.Error("Cache contains resolved configuration")
+	}
+
+	InitAsStruct(Object{})
+	atomic.StoreUint32(&down, 1)
+
+	err = InitURL(server.URL, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsStale() || Int64("version") != 1 || String("dsn") != "postgres://localhost" {
+		t.Error("Configuration isn't loaded from cache")
+	}
+
+	atomic.StoreUint32(&down, 0)
+	atomic.StoreInt64(&version, 2)
+
+	err = Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsStale() || Int64("version") != 2 {
+		t.Error("Configuration from source is stale")
+	}
+
+	err = ioutil.WriteFile(cachePath, []byte(strings.Replace(string(bs), `"version":1`, `"version":3`, 1)), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	InitAsStruct(Object{})
+	atomic.StoreUint32(&down, 1)
+
+	err = InitURL(server.URL, options...)
+	if err == nil {
+		t.Error("Cache with mismatched checksum is loaded")
+	}
+
+	err = writeCache(cachePath, "http://other.local", []byte(`{"version": 4}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = InitURL(server.URL, options...)
+	if err == nil {
+		t.Error("Cache of other source is loaded")
+	}
+}
+
+type flakyKV struct {
+	*MemoryKV
+	down uint32
+}
+
+func (kv *flakyKV) List(ctx context.Context, prefix string) (values map[string]string, err error) {
+	if atomic.LoadUint32(&kv.down) == 1 {
+		return nil, errors.New("unreachable")
+	}
+
+	return kv.MemoryKV.List(ctx, prefix)
+}
+
+func TestCacheKV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kv := &flakyKV{MemoryKV: NewMemoryKV(map[string]string{
+		"app/db/host": "localhost",
+		"app/db/port": "5432",
+	})}
+
+	cachePath := filepath.Join(dir, "config.cache")
+
+	err = InitKV(kv, "app/", NoPolling(), Cache(cachePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	InitAsStruct(Object{})
+	atomic.StoreUint32(&kv.down, 1)
+
+	err = InitKV(kv, "app/", NoPolling(), Cache(cachePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsStale() || String("db.host") != "localhost" {
+		t.Error("Configuration isn't loaded from cache")
+	}
+
+	changes := make(chan interface{}, 1)
+	h := OnChange("db.host", func(old, new interface{}) {
+		changes <- new
+	})
+	defer h.Unregister()
+
+	kv.Put("app/db/host", "db.local")
+	time.Sleep(100 * time.Millisecond)
+
+	if String("db.host") != "localhost" || Int64("db.port") != 5432 {
+		t.Error("Change is applied to configuration loaded from cache")
+	}
+
+	cached, err := readCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cached.Object), `"port":"5432"`) {
+		t.Errorf("Cache is overwritten with partial configuration: %s", cached.Object)
+	}
+
+	atomic.StoreUint32(&kv.down, 0)
+	kv.Put("app/db/host", "db.remote")
+
+	select {
+	case val := <-changes:
+		if val != "db.remote" || Int64("db.port") != 5432 || IsStale() {
+			t.Error("Configuration isn't listed from source on change")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Change isn't applied after source is reachable")
+	}
+}
+
+func TestCacheChecksum(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cachePath := filepath.Join(dir, "config.cache")
+
+	err = writeCache(cachePath, "http://localhost", []byte(`{"url": "http://x/?a=1&b=<2>"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := readCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cached.Object) != `{"url":"http://x/?a=1&b=<2>"}` {
+		t.Errorf("Cache contains unexpected object: %s", cached.Object)
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallbackOption sets how a callback is executed on refresh
+type CallbackOption func(o *callbackOptions)
+
+type callbackOptions struct {
+	timeout time.Duration
+	async   bool
+}
+
+// Timeout stops waiting for the callback after the duration to continue the reload,
+// the callback itself isn't interrupted and the timeout is reported through the error logger
+func Timeout(timeout time.Duration) CallbackOption {
+	return func(o *callbackOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Async runs the callback in its own goroutine without waiting for it
+func Async() CallbackOption {
+	return func(o *callbackOptions) {
+		o.async = true
+	}
+}
+
+// Handle is returned by callback registration to unregister the callback
+type Handle struct {
+	once       sync.Once
+	unregister func()
+}
+
+// Unregister removes the callback, it's safe to call it several times
+func (h *Handle) Unregister() {
+	h.once.Do(h.unregister)
+}
+
+type refresher struct {
+	id       uint64
+	callback func()
+	options  callbackOptions
+}
+
+var (
+	refresherID uint64
+	refresherMx = &sync.Mutex{}
+)
+
+func addRefresher(callback func(), options []CallbackOption) (h *Handle) {
+	r := refresher{
+		id:       atomic.AddUint64(&refresherID, 1),
+		callback: callback,
+	}
+	for _, option := range options {
+		option(&r.options)
+	}
+
+	refresherMx.Lock()
+	defer refresherMx.Unlock()
+
+	list := refreshers.Load().([]refresher)
+	refreshers.Store(append(append([]refresher{}, list...), r))
+
+	return &Handle{
+		unregister: func() {
+			refresherMx.Lock()
+			defer refresherMx.Unlock()
+
+			var list []refresher
+			for _, item := range refreshers.Load().([]refresher) {
+				if item.id != r.id {
+					list = append(list, item)
+				}
+			}
+			refreshers.Store(list)
+
+			logger.Load().(logFn).debug("Remove callback on refresh")
+		},
+	}
+}
+
+// runCallback runs the callback with panic recovery and the options
+func runCallback(name string, callback func(), options callbackOptions) {
+	done := make(chan struct{})
+
+	run := func() {
+		defer close(done)
+		defer func() {
+			r := recover()
+			if r != nil {
+				logger.Load().(logFn).error(fmt.Sprintf("Callback %s panics: %v", name, r),
+					slog.String("callback", name),
+					slog.String("panic", fmt.Sprintf("%v", r)),
+					slog.String("stack", string(debug.Stack())))
+			}
+		}()
+
+		callback()
+	}
+
+	if options.async {
+		go run()
+
+		return
+	}
+
+	if options.timeout <= 0 {
+		run()
+
+		return
+	}
+
+	go run()
+
+	select {
+	case <-done:
+	case <-time.After(options.timeout):
+		logger.Load().(logFn).error(fmt.Sprintf("Callback %s isn't finished in %v", name, options.timeout),
+			slog.String("callback", name),
+			slog.Duration("timeout", options.timeout))
+	}
+}
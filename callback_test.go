@@ -0,0 +1,162 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSafeCallbacks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"name": "app"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reload := func(modTime time.Time) {
+		err := os.Chtimes(cfgPath, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = refreshJson()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = Init(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	var errors []string
+	Error(func(message string) {
+		errors = append(errors, message)
+	})
+	defer Error(func(message string) {})
+
+	var (
+		calls   int32
+		release = make(chan struct{})
+	)
+	panicking := Refresh(func() {
+		panic("broken callback")
+	})
+	counting := Refresh(func() {
+		atomic.AddInt32(&calls, 1)
+	})
+	slow := Refresh(func() {
+		<-release
+	}, Timeout(10*time.Millisecond))
+	async := Refresh(func() {
+		<-release
+	}, Async())
+
+	reload(time.Now().Add(time.Hour))
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Error("Callback isn't called after panic of previous callback")
+	}
+
+	var panicked, timedOut bool
+	for _, message := range errors {
+		panicked = panicked || strings.Contains(message, "broken callback")
+		timedOut = timedOut || strings.Contains(message, "isn't finished")
+	}
+	if !panicked || !timedOut {
+		t.Errorf("Panic or timeout of callback isn't reported: %v", errors)
+	}
+
+	panicking.Unregister()
+	counting.Unregister()
+	counting.Unregister()
+	slow.Unregister()
+	async.Unregister()
+	close(release)
+
+	reload(time.Now().Add(2 * time.Hour))
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Error("Unregistered callback is called")
+	}
+}
+
+func TestReentrantCallbacks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 1}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath, NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	var (
+		saved    int32
+		reloaded int32
+		errs     = make(chan error, 2)
+	)
+	refresh := Refresh(func() {
+		if atomic.AddInt32(&saved, 1) == 1 {
+			errs <- Save(cfgPath, JSON)
+		}
+	})
+	defer refresh.Unregister()
+
+	change := OnChange("version", func(old, new interface{}) {
+		if atomic.AddInt32(&reloaded, 1) == 1 {
+			errs <- Reload()
+		}
+	})
+	defer change.Unregister()
+
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 2}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Reload()
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reload is blocked by callback that saves or reloads configuration")
+	}
+
+	for i := 0; i < 2; i++ {
+		err = <-errs
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if Int64("version") != 2 {
+		t.Error("Configuration isn't reloaded")
+	}
+}
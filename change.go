@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type subscription struct {
+	id       uint64
+	pattern  string
+	callback func(event ChangeEvent)
+}
+
+var (
+	subscriptions  = &atomic.Value{}
+	subscriptionID uint64
+	subscriptionMx = &sync.Mutex{}
+)
+
+func init() {
+	subscriptions.Store([]subscription{})
+}
+
+// OnChange adds callback on changes of the value by the path (the value is compared with its subtree)
+// or by every path matched by the pattern with `*` segments (e.g. `services.*.port`);
+// old or new value is nil if the path is added or removed; the callback is run like Refresh callbacks
+func OnChange(pattern string, callback func(old, new interface{}), options ...CallbackOption) *Handle {
+	var opts callbackOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	id := subscribe(pattern, func(event ChangeEvent) {
+		runCallback(fmt.Sprintf("on change of `%s`", event.Path), func() {
+			callback(event.Old, event.New)
+		}, opts)
+	})
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Add callback on change of `%s`", pattern))
+
+	return &Handle{
+		unregister: func() {
+			unsubscribe(id)
+
+			logger.Load().(logFn).debug(fmt.Sprintf("Remove callback on change of `%s`", pattern))
+		},
+	}
+}
+
+func subscribe(pattern string, callback func(event ChangeEvent)) (id uint64) {
+	subscriptionMx.Lock()
+	defer subscriptionMx.Unlock()
+
+	id = atomic.AddUint64(&subscriptionID, 1)
+
+	s := subscriptions.Load().([]subscription)
+	s = append(append([]subscription{}, s...), subscription{
+		id:       id,
+		pattern:  pattern,
+		callback: callback,
+	})
+	subscriptions.Store(s)
+
+	return
+}
+
+func unsubscribe(id uint64) {
+	subscriptionMx.Lock()
+	defer subscriptionMx.Unlock()
+
+	var s []subscription
+	for _, sub := range subscriptions.Load().([]subscription) {
+		if sub.id != id {
+			s = append(s, sub)
+		}
+	}
+	subscriptions.Store(s)
+}
+
+// notifyChanges notifies subscribers about changes between the previous configuration and the configuration
+// committed as the generation from the source
+func notifyChanges(prev, next Object, gen uint64, src string) {
+	subs := subscriptions.Load().([]subscription)
+	if len(subs) == 0 {
+		return
+	}
+
+	changes := Diff(prev, next)
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, s := range subs {
+		for _, path := range matchedPaths(s.pattern, prev, next) {
+			if !isAffected(path, changes) {
+				continue
+			}
+
+			old, _ := prev.Interface(path)
+			val, _ := next.Interface(path)
+
+			logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is changed", path))
+
+			s.callback(ChangeEvent{
+				Path:       path,
+				Old:        old,
+				New:        val,
+				Generation: gen,
+				Source:     src,
+			})
+		}
+	}
+}
+
+// isAffected checks one of the changes is by the path, inside its subtree or replaces one of its parents
+func isAffected(path string, changes []Change) bool {
+	for _, c := range changes {
+		if c.Path == path ||
+			strings.HasPrefix(c.Path, path+".") || strings.HasPrefix(c.Path, path+"[") ||
+			strings.HasPrefix(path, c.Path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchedPaths(pattern string, objects ...Object) (paths []string) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}
+	}
+
+	set := map[string]bool{}
+	for _, obj := range objects {
+		collectPaths(map[string]interface{}(obj), "", set)
+	}
+
+	for path := range set {
+		if matchPath(pattern, path) {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return
+}
+
+func collectPaths(v interface{}, path string, set map[string]bool) {
+	if len(path) > 0 {
+		set[path] = true
+	}
+
+	m, ok := asMap(v)
+	if !ok {
+		return
+	}
+
+	for key, item := range m {
+		collectPaths(item, joinPath(path, key), set)
+	}
+}
+
+// matchPath matches the path with the pattern segment by segment
+func matchPath(pattern, path string) bool {
+	patternSegments := strings.Split(pattern, ".")
+	pathSegments := strings.Split(path, ".")
+
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		ok, _ := pathpkg.Match(segment, pathSegments[i])
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
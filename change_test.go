@@ -0,0 +1,187 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnChange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	write := func(content string, modTime time.Time) {
+		err := ioutil.WriteFile(cfgPath, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.Chtimes(cfgPath, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"db": {"pool": {"size": 10}, "host": "a"}, "services": {"api": {"port": 80}, "web": {"port": 81}}}`, time.Now())
+
+	err = Init(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	var (
+		pool  []interface{}
+		host  int
+		ports = map[interface{}]interface{}{}
+	)
+	OnChange("db.pool", func(old, new interface{}) {
+		pool = append(pool, old, new)
+	})
+	OnChange("db.host", func(old, new interface{}) {
+		host++
+	})
+	OnChange("services.*.port", func(old, new interface{}) {
+		ports[old] = new
+	})
+
+	write(`{"db": {"pool": {"size": 20}, "host": "a"}, "services": {"api": {"port": 80}, "web": {"port": 82}}}`,
+		time.Now().Add(time.Hour))
+
+	err = refreshJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pool) != 2 {
+		t.Fatal("Callback on change of `db.pool` isn't fired")
+	}
+
+	old, _ := Object{"pool": pool[0]}.Int64("pool.size")
+	val, _ := Object{"pool": pool[1]}.Int64("pool.size")
+	if old != 10 || val != 20 {
+		t.Error("Callback on change of `db.pool` receives unexpected values")
+	}
+
+	if host != 0 {
+		t.Error("Callback on change of `db.host` is fired without changes")
+	}
+
+	if len(ports) != 1 {
+		t.Errorf("Callback on change of `services.*.port` is fired unexpected times: %v", ports)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"db": {"pool": 10}, "name": "app"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := Watch(ctx, "db.pool")
+	all := Watch(ctx)
+
+	for i := 1; i <= WatchBuffer+1; i++ {
+		modTime := time.Now().Add(time.Duration(i) * time.Hour)
+		err = ioutil.WriteFile(cfgPath, []byte(fmt.Sprintf(`{"db": {"pool": %d}, "name": "app"}`, 10+i)), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(cfgPath, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = refreshJson()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(pool) != WatchBuffer {
+		t.Errorf("Channel contains unexpected number of events: %d", len(pool))
+	}
+
+	event := <-pool
+	if event.Path != "db.pool" || event.Source != cfgPath || event.Generation == 0 {
+		t.Errorf("Event contains unexpected values: %+v", event)
+	}
+
+	old, _ := Object{"v": event.Old}.Int64("v")
+	if old != 11 {
+		t.Errorf("The oldest event isn't dropped: %+v", event)
+	}
+
+	event = <-all
+	if event.Path != "db" {
+		t.Errorf("Event of top-level key contains unexpected values: %+v", event)
+	}
+
+	cancel()
+
+	for range pool {
+	}
+	for range all {
+	}
+}
+
+func TestWatchOrder(t *testing.T) {
+	InitAsStruct(Object{"v": 0})
+	defer InitAsStruct(Object{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := Watch(ctx, "v")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= WatchBuffer/2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer notifyCommitted()
+
+			_, err := commit(Object{"v": i}, fmt.Sprintf("src-%d", i), nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var gen uint64
+	for i := 0; i < WatchBuffer/2; i++ {
+		event := <-events
+		if event.Generation <= gen {
+			t.Errorf("Events aren't sent in commit order: %d after %d", event.Generation, gen)
+		}
+		if event.Source != fmt.Sprintf("src-%v", event.New) {
+			t.Errorf("Event contains source of other commit: %+v", event)
+		}
+
+		gen = event.Generation
+	}
+}
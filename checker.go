@@ -15,10 +15,13 @@ type Checker struct {
 type Handler func(v interface{}) (err error)
 
 type Rule struct {
-	IsRequired bool
-	Type       Type
-	RegExp     *regexp.Regexp
-	Handler    Handler
+	IsRequired  bool
+	Type        Type
+	RegExp      *regexp.Regexp
+	Handler     Handler
+	Layout      string
+	Items       Type
+	IsSensitive bool
 }
 
 type RuleSet map[string]Rule
@@ -78,6 +81,15 @@ const (
 	SliceType    Type = "slice"
 	MapType      Type = "map"
 	DurationType Type = "duration"
+	BytesizeType Type = "bytesize"
+	TimeType     Type = "time"
+	DateType     Type = "date"
+	LocationType Type = "location"
+	URLType      Type = "url"
+	IPType       Type = "ip"
+	CIDRType     Type = "cidr"
+	HostPortType Type = "hostport"
+	RegexpType   Type = "regexp"
 )
 
 func NewChecker(jsonRules []byte, handlerBinds map[string]Handler) (c *Checker, err error) {
@@ -143,6 +155,9 @@ func (c *Checker) walkRules(v interface{}) (set RuleSet, err error) {
 		delete(subHeap, "type")
 		delete(subHeap, "regexp")
 		delete(subHeap, "handler")
+		delete(subHeap, "layout")
+		delete(subHeap, "items")
+		delete(subHeap, "sensitive")
 
 		if len(subHeap) > 0 {
 			subRuleSet, err = c.walkRules(val)
@@ -227,6 +242,47 @@ func (c *Checker) parseRule(v map[string]interface{}) (rule Rule, err error) {
 		}
 	}
 
+	val, ok = v["layout"]
+	if ok {
+		rule.Layout, ok = val.(string)
+		if !ok {
+			err = &UnexpectedRule{
+				message: fmt.Sprintf("can't parse rule `%v` because of wrong `layout`", v),
+			}
+
+			return
+		}
+	}
+
+	val, ok = v["items"]
+	if ok {
+		str, ok = val.(string)
+		if !ok {
+			err = &UnexpectedRule{
+				message: fmt.Sprintf("can't parse rule `%v` because of wrong `items`", v),
+			}
+
+			return
+		}
+
+		rule.Items, err = convType(str)
+		if err != nil {
+			return
+		}
+	}
+
+	val, ok = v["sensitive"]
+	if ok {
+		rule.IsSensitive, ok = val.(bool)
+		if !ok {
+			err = &UnexpectedRule{
+				message: fmt.Sprintf("can't parse rule `%v` because of wrong `sensitive`", v),
+			}
+
+			return
+		}
+	}
+
 	val, ok = v["handler"]
 	if ok {
 		str, ok = val.(string)
@@ -275,6 +331,24 @@ func convType(str string) (t Type, err error) {
 		t = MapType
 	case "duration":
 		t = DurationType
+	case "bytesize":
+		t = BytesizeType
+	case "time":
+		t = TimeType
+	case "date":
+		t = DateType
+	case "location":
+		t = LocationType
+	case "url":
+		t = URLType
+	case "ip":
+		t = IPType
+	case "cidr":
+		t = CIDRType
+	case "hostport":
+		t = HostPortType
+	case "regexp":
+		t = RegexpType
 	default:
 		err = &UnexpectedType{
 			message: fmt.Sprintf("can't parse type `%v`", str),
@@ -284,6 +358,17 @@ func convType(str string) (t Type, err error) {
 	return
 }
 
+// SensitivePaths returns paths of rules with `sensitive` flag to pass them into Sensitive
+func (c *Checker) SensitivePaths() (paths []string) {
+	for path, rule := range c.RuleSet {
+		if rule.IsSensitive {
+			paths = append(paths, path)
+		}
+	}
+
+	return
+}
+
 func (c *Checker) Check(obj Object) (err error) {
 	var ok bool
 
@@ -301,33 +386,10 @@ func (c *Checker) Check(obj Object) (err error) {
 			}
 		}
 
-		switch rule.Type {
-		case StringType:
-			ok = obj.IsString(path)
-		case BoolType:
-			ok = obj.IsBool(path)
-		case Int32Type:
-			ok = obj.IsInt32(path)
-		case UInt32Type:
-			ok = obj.IsUInt32(path)
-		case Int64Type:
-			ok = obj.IsInt64(path)
-		case UInt64Type:
-			ok = obj.IsUInt64(path)
-		case Float32Type:
-			ok = obj.IsFloat32(path)
-		case Float64Type:
-			ok = obj.IsFloat64(path)
-		case ListType:
-			ok = obj.IsList(path)
-		case SliceType:
-			ok = obj.IsSlice(path)
-		case MapType:
-			ok = obj.IsMap(path)
-		case DurationType:
-			ok = obj.IsDuration(path)
-		default:
-			ok = false
+		ok = checkType(obj, path, rule.Type, rule.Layout)
+
+		if ok && len(rule.Items) > 0 {
+			ok = checkItems(obj, path, rule.Items, rule.Layout)
 		}
 
 		if !ok {
@@ -383,3 +445,72 @@ func (c *Checker) Check(obj Object) (err error) {
 
 	return
 }
+
+func checkType(obj Object, path string, t Type, layout string) (ok bool) {
+	switch t {
+	case StringType:
+		ok = obj.IsString(path)
+	case BoolType:
+		ok = obj.IsBool(path)
+	case Int32Type:
+		ok = obj.IsInt32(path)
+	case UInt32Type:
+		ok = obj.IsUInt32(path)
+	case Int64Type:
+		ok = obj.IsInt64(path)
+	case UInt64Type:
+		ok = obj.IsUInt64(path)
+	case Float32Type:
+		ok = obj.IsFloat32(path)
+	case Float64Type:
+		ok = obj.IsFloat64(path)
+	case ListType:
+		ok = obj.IsList(path)
+	case SliceType:
+		ok = obj.IsSlice(path)
+	case MapType:
+		ok = obj.IsMap(path)
+	case DurationType:
+		ok = obj.IsDuration(path)
+	case BytesizeType:
+		ok = obj.IsBytes(path)
+	case TimeType:
+		if len(layout) > 0 {
+			ok = obj.IsTime(path, layout)
+		} else {
+			ok = obj.IsTime(path)
+		}
+	case DateType:
+		ok = obj.IsDate(path)
+	case LocationType:
+		ok = obj.IsLocation(path)
+	case URLType:
+		ok = obj.IsURL(path)
+	case IPType:
+		ok = obj.IsIP(path)
+	case CIDRType:
+		ok = obj.IsIPNet(path)
+	case HostPortType:
+		ok = obj.IsHostPort(path)
+	case RegexpType:
+		ok = obj.IsRegexp(path)
+	default:
+		ok = false
+	}
+
+	return
+}
+
+func checkItems(obj Object, path string, t Type, layout string) (ok bool) {
+	err := obj.each(path, func(item Object) (err error) {
+		if !checkType(item, itemKey, t, layout) {
+			err = fmt.Errorf("item has wrong `type`")
+		}
+
+		return
+	})
+
+	ok = err == nil
+
+	return
+}
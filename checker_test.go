@@ -391,3 +391,104 @@ func durationHandler(v interface{}) (err error) {
 
 	return
 }
+
+func TestCheckerDuration(t *testing.T) {
+	checker, err := NewChecker([]byte(`{"timeout": {"required": true, "type": "duration"}}`), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, val := range []interface{}{"1m30s", "PT5M", float64(5)} {
+		err = checker.Check(Object{"timeout": val})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	err = checker.Check(Object{"timeout": "soon"})
+	if err == nil {
+		t.Error("Checker accepts wrong duration")
+	}
+}
+
+func TestCheckerBytesize(t *testing.T) {
+	checker, err := NewChecker([]byte(`{"buffer": {"required": true, "type": "bytesize"}}`), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = checker.Check(Object{"buffer": "64MB"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = checker.Check(Object{"buffer": "64 parsecs"})
+	if err == nil {
+		t.Error("Checker accepts wrong byte size")
+	}
+}
+
+func TestCheckerTime(t *testing.T) {
+	checker, err := NewChecker([]byte(`{"window": {
+	"start": {"required": true, "type": "time", "layout": "15:04"},
+	"cutoff": {"required": true, "type": "date"},
+	"zone": {"required": true, "type": "location"}
+}}`), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj := Object{"window": map[string]interface{}{"start": "03:30", "cutoff": "2026-01-02", "zone": "UTC"}}
+	err = checker.Check(obj)
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj = Object{"window": map[string]interface{}{"start": "3.30am", "cutoff": "2026-01-02", "zone": "UTC"}}
+	err = checker.Check(obj)
+	if err == nil {
+		t.Error("Checker accepts wrong time")
+	}
+}
+
+func TestCheckerNetwork(t *testing.T) {
+	checker, err := NewChecker([]byte(`{
+	"url": {"required": true, "type": "url"},
+	"ip": {"required": true, "type": "ip"},
+	"cidr": {"required": true, "type": "cidr"},
+	"addr": {"required": true, "type": "hostport"},
+	"re": {"required": true, "type": "regexp"}
+}`), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj := Object{"url": "https://example.com", "ip": "10.0.0.1", "cidr": "10.0.0.0/8", "addr": ":8080", "re": "^a$"}
+	err = checker.Check(obj)
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj["ip"] = "10.0.0.256"
+	err = checker.Check(obj)
+	if err == nil {
+		t.Error("Checker accepts wrong ip address")
+	}
+}
+
+func TestCheckerItems(t *testing.T) {
+	checker, err := NewChecker([]byte(`{"ports": {"required": true, "type": "slice", "items": "uint32"}}`), nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = checker.Check(Object{"ports": []interface{}{float64(80), "443"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = checker.Check(Object{"ports": []interface{}{float64(80), "https"}})
+	if err == nil {
+		t.Error("Checker accepts wrong item type")
+	}
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+type commitHook struct {
+	id     uint64
+	name   string
+	before func(candidate Object) error
+	after  func(active Object) error
+}
+
+var (
+	commitHooks  = &atomic.Value{}
+	commitHookID uint64
+	commitHookMx = &sync.Mutex{}
+	commitMx     = &sync.Mutex{}
+
+	committed   []commitment
+	committedMx = &sync.Mutex{}
+	notifying   uint32
+)
+
+// commitment is a committed configuration that refresh callbacks and change subscribers aren't notified about yet
+type commitment struct {
+	prev Object
+	next Object
+	gen  uint64
+	src  string
+}
+
+func init() {
+	commitHooks.Store([]commitHook{})
+}
+
+// BeforeCommit adds hook called with the candidate configuration before it becomes active,
+// an error of any hook aborts the reload and the active configuration stays unchanged
+func BeforeCommit(hook func(candidate Object) error) *Handle {
+	return addCommitHook(commitHook{name: "before commit", before: hook})
+}
+
+// AfterCommit adds hook called with the new active configuration, an error of any hook rolls back
+// to the previous configuration and hooks that have already accepted the new one are called again with the previous one
+func AfterCommit(hook func(active Object) error) *Handle {
+	return addCommitHook(commitHook{name: "after commit", after: hook})
+}
+
+func addCommitHook(h commitHook) *Handle {
+	h.id = atomic.AddUint64(&commitHookID, 1)
+
+	commitHookMx.Lock()
+	list := commitHooks.Load().([]commitHook)
+	commitHooks.Store(append(append([]commitHook{}, list...), h))
+	commitHookMx.Unlock()
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Add %s hook", h.name))
+
+	return &Handle{
+		unregister: func() {
+			commitHookMx.Lock()
+			defer commitHookMx.Unlock()
+
+			var list []commitHook
+			for _, item := range commitHooks.Load().([]commitHook) {
+				if item.id != h.id {
+					list = append(list, item)
+				}
+			}
+			commitHooks.Store(list)
+
+			logger.Load().(logFn).debug(fmt.Sprintf("Remove %s hook", h.name))
+		},
+	}
+}
+
+// commit makes the object active configuration if every hook accepts it and returns its generation,
+// an error of AfterCommit hook rolls it back; sensitive and derived are paths of values that can't be saved (see Save).
+// Refresh callbacks and change subscribers aren't run by commit, the caller runs them by notifyCommitted
+// after the load lock is released, so callbacks can reload or save the configuration.
+func commit(obj Object, src string, sensitive, derived []string) (gen uint64, err error) {
+	commitMx.Lock()
+	defer commitMx.Unlock()
+
+	hooks := commitHooks.Load().([]commitHook)
+
+	for _, h := range hooks {
+		if h.before == nil {
+			continue
+		}
+
+		err = runHook(h.name, h.before, obj)
+		if err != nil {
+			err = fmt.Errorf("configuration is rejected: %v", err)
+
+			return
+		}
+	}
+
+	prev := cfg.Load().(Object)
+	prevSource, prevSensitive, prevDerived := source.Load().(string), sensitivePaths.Load(), derivedPaths.Load()
+
+	cfg.Store(obj)
+	source.Store(src)
+	storeSensitivePaths(sensitive)
+	derivedPaths.Store(derived)
+
+	var applied []commitHook
+	for _, h := range hooks {
+		if h.after == nil {
+			continue
+		}
+
+		err = runHook(h.name, h.after, obj)
+		if err != nil {
+			err = fmt.Errorf("configuration is rolled back: %v", err)
+
+			cfg.Store(prev)
+			source.Store(prevSource)
+			sensitivePaths.Store(prevSensitive)
+			derivedPaths.Store(prevDerived)
+
+			for i := len(applied) - 1; i >= 0; i-- {
+				e := runHook(applied[i].name, applied[i].after, prev)
+				if e != nil {
+					logger.Load().(logFn).error(fmt.Sprintf("Can't roll back configuration: %v", e),
+						slog.String("error_type", fmt.Sprintf("%T", e)))
+				}
+			}
+
+			return
+		}
+
+		applied = append(applied, h)
+	}
+
+	gen = atomic.AddUint64(&generation, 1)
+
+	remember(gen, obj, src, sensitive, derived)
+
+	committedMx.Lock()
+	committed = append(committed, commitment{prev: prev, next: obj, gen: gen, src: src})
+	committedMx.Unlock()
+
+	return
+}
+
+// notifyCommitted runs refresh callbacks and notifies change subscribers about committed configurations
+// in the commit order; if it's called from a callback (e.g. the callback reloads the configuration),
+// the new commitment is left to the running notification
+func notifyCommitted() {
+	for atomic.CompareAndSwapUint32(&notifying, 0, 1) {
+		for {
+			committedMx.Lock()
+			if len(committed) == 0 {
+				committedMx.Unlock()
+
+				break
+			}
+
+			c := committed[0]
+			committed = committed[1:]
+			committedMx.Unlock()
+
+			for _, r := range refreshers.Load().([]refresher) {
+				runCallback("on refresh", r.callback, r.options)
+			}
+
+			notifyChanges(c.prev, c.next, c.gen, c.src)
+		}
+
+		atomic.StoreUint32(&notifying, 0)
+
+		committedMx.Lock()
+		empty := len(committed) == 0
+		committedMx.Unlock()
+
+		if empty {
+			return
+		}
+	}
+}
+
+func runHook(name string, hook func(obj Object) error, obj Object) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("%s hook panics: %v", name, r)
+		}
+	}()
+
+	return hook(obj)
+}
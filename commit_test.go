@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTransactionalReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	write := func(content string, modTime time.Time) {
+		err := ioutil.WriteFile(cfgPath, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.Chtimes(cfgPath, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"cert": "good", "pool": 1}`, time.Now())
+
+	err = Init(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	var (
+		refreshed int
+		applied   []string
+	)
+	handles := []*Handle{
+		Refresh(func() {
+			refreshed++
+		}),
+		BeforeCommit(func(candidate Object) error {
+			cert, _ := candidate.String("cert")
+			if cert == "bad" {
+				return fmt.Errorf("can't load certificate")
+			}
+
+			return nil
+		}),
+		AfterCommit(func(active Object) error {
+			pool, _ := active.String("pool")
+			applied = append(applied, pool)
+
+			return nil
+		}),
+		AfterCommit(func(active Object) error {
+			pool, _ := active.Int64("pool")
+			if pool > 10 {
+				return fmt.Errorf("pool is too big")
+			}
+
+			return nil
+		}),
+	}
+	defer func() {
+		for _, h := range handles {
+			h.Unregister()
+		}
+	}()
+
+	write(`{"cert": "bad", "pool": 2}`, time.Now().Add(time.Hour))
+
+	err = refreshJson()
+	if err == nil {
+		t.Error("Rejected configuration doesn't return error")
+	}
+	if String("cert") != "good" || refreshed != 0 || len(applied) != 0 {
+		t.Error("Rejected configuration is applied")
+	}
+
+	write(`{"cert": "good", "pool": 20}`, time.Now().Add(2*time.Hour))
+
+	err = refreshJson()
+	if err == nil {
+		t.Error("Rolled back configuration doesn't return error")
+	}
+	if Int64("pool") != 1 || refreshed != 0 {
+		t.Error("Configuration isn't rolled back")
+	}
+	if len(applied) != 2 || applied[0] != "20" || applied[1] != "1" {
+		t.Errorf("Accepted hooks aren't called with previous configuration: %v", applied)
+	}
+
+	write(`{"cert": "good", "pool": 5}`, time.Now().Add(3*time.Hour))
+
+	err = refreshJson()
+	if err != nil {
+		t.Error(err)
+	}
+	if Int64("pool") != 5 || refreshed != 1 {
+		t.Error("Accepted configuration isn't applied")
+	}
+}
@@ -1,36 +1,47 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"log/slog"
+	"net"
+	"net/url"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 var (
-	once = &sync.Once{}
-
-	filePath  = &atomic.Value{}
-	format    = &atomic.Value{}
-	isLoaded  uint32
-	timestamp int64
-	cfg       = &atomic.Value{}
+	loadMx     = &sync.Mutex{}
+	modTimesMx = &sync.Mutex{}
+
+	filePath   = &atomic.Value{}
+	format     = &atomic.Value{}
+	isLoaded   uint32
+	generation uint64
+	modTimes   = &atomic.Value{}
+	globs      = &atomic.Value{}
+	source     = &atomic.Value{}
+	cfg        = &atomic.Value{}
 
 	logger = &atomic.Value{}
 
 	withRefresh uint32
 	refreshers  = &atomic.Value{}
+
+	durationUnit = int64(time.Second)
 )
 
 type logFn struct {
-	debug func(message string)
-	info  func(message string)
-	warn  func(message string)
-	error func(message string)
+	debug func(message string, attrs ...slog.Attr)
+	info  func(message string, attrs ...slog.Attr)
+	warn  func(message string, attrs ...slog.Attr)
+	error func(message string, attrs ...slog.Attr)
 }
 
 func init() {
@@ -38,122 +49,155 @@ func init() {
 	cfg.Store(obj)
 
 	logger.Store(logFn{
-		debug: func(message string) {},
-		info:  func(message string) {},
-		warn:  func(message string) {},
-		error: func(message string) {},
+		debug: func(message string, attrs ...slog.Attr) {},
+		info:  func(message string, attrs ...slog.Attr) {},
+		warn:  func(message string, attrs ...slog.Attr) {},
+		error: func(message string, attrs ...slog.Attr) {},
 	})
 
-	refreshers.Store([]func(){})
-}
+	refreshers.Store([]refresher{})
+
+	modTimes.Store(map[string]int64{})
+	globs.Store(map[string][]string{})
 
-// Init sets file path to a file with configuration (JSON format) and set periodically refresh data from its
-func Init(cfgPath string) (err error) {
-	atomic.StoreUint32(&withRefresh, 1)
+	source.Store("")
+}
 
+// Init sets file path to a file with configuration (JSON format) and set periodically refresh data from its,
+// options change how changes of the file are detected (see ReloadOnSignal and NoPolling)
+func Init(cfgPath string, options ...InitOption) (err error) {
 	cfgPath, err = filepath.Abs(cfgPath)
 	if err != nil {
 		return
 	}
 
-	_, err = os.Stat(cfgPath)
-	if err != nil {
-		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+	return initFile(fileSystem{}, cfgPath, options)
+}
 
-		return
-	}
+// InitAsStruct sets interface (Object struct) as configuration
+func InitAsStruct(obj Object) {
+	atomic.StoreUint32(&withRefresh, 0)
+	atomic.StoreUint32(&stale, 0)
+	unwatch()
 
-	filePath.Store(cfgPath)
+	cfg.Store(obj)
+	source.Store("")
+	storeSensitivePaths()
+	derivedPaths.Store([]string{})
+}
 
-	logger.Load().(logFn).info("Configuration is initialized")
+func refreshJson() (err error) {
+	return loadJson(false)
+}
 
-	err = refreshJson()
-	if err != nil {
+// loadJson loads the config file if it's modified or if the load is forced
+func loadJson(force bool) (err error) {
+	if atomic.LoadUint32(&withRefresh) != 1 {
 		return
 	}
 
-	once.Do(func() {
-		go func() {
-			var e error
+	defer notifyCommitted()
 
-			for range time.NewTicker(time.Second).C {
-				e = refreshJson()
+	loadMx.Lock()
+	defer loadMx.Unlock()
 
-				if e != nil {
-					logger.Load().(logFn).error(e.Error())
-				}
-			}
-		}()
-	})
+	cfgPath := filePath.Load().(string)
 
-	return
-}
+	if !force && atomic.LoadUint32(&isLoaded) == 1 && !isModified(cfgPath) {
+		return
+	}
 
-// InitAsStruct sets interface (Object struct) as configuration
-func InitAsStruct(obj Object) {
-	atomic.StoreUint32(&withRefresh, 0)
+	start := time.Now()
 
-	cfg.Store(obj)
-}
+	inc := newIncluder(fileSys.Load().(fileSystem))
 
-func refreshJson() (err error) {
-	if atomic.LoadUint32(&withRefresh) != 1 {
+	var obj Object
+	obj, err = inc.load(cfgPath)
+	if err != nil {
 		return
 	}
 
-	cfgPath := filePath.Load().(string)
+	var sensitive, derived []string
+	obj, sensitive, derived, err = prepare(obj)
+	if err != nil {
+		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	modTimesMx.Lock()
+	modTimes.Store(inc.files)
+	globs.Store(inc.globs)
+	modTimesMx.Unlock()
 
-	var info os.FileInfo
-	info, err = os.Stat(cfgPath)
+	var gen uint64
+	gen, err = commit(obj, cfgPath, sensitive, append(inc.directives, derived...))
 	if err != nil {
 		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
 
 		return
 	}
 
-	if atomic.LoadUint32(&isLoaded) == 0 || atomic.LoadInt64(&timestamp) != info.ModTime().Unix() {
-		var bs []byte
-		bs, err = ioutil.ReadFile(cfgPath)
-		if err != nil {
-			err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+	attrs := []slog.Attr{
+		slog.String("file", cfgPath),
+		slog.Uint64("generation", gen),
+		slog.Duration("duration", time.Since(start)),
+	}
 
-			return
-		}
+	if atomic.LoadUint32(&isLoaded) == 0 {
+		logger.Load().(logFn).info("Configuration is loaded", attrs...)
+	} else {
+		logger.Load().(logFn).info("Configuration is reloaded", attrs...)
+	}
 
-		var obj Object
-		err = json.Unmarshal(bs, &obj)
-		if err != nil {
-			err = fmt.Errorf("file %s isn't suported configuration", cfgPath)
+	atomic.StoreUint32(&isLoaded, 1)
 
-			return
-		}
-		cfg.Store(obj)
+	return
+}
 
-		atomic.StoreInt64(&timestamp, info.ModTime().Unix())
+// isModified checks the config file, all included files and matches of include globs
+func isModified(cfgPath string) bool {
+	files := modTimes.Load().(map[string]int64)
 
-		if atomic.LoadUint32(&isLoaded) == 0 {
-			logger.Load().(logFn).info("Configuration is loaded")
-		} else {
-			logger.Load().(logFn).info("Configuration is reloaded")
-		}
+	_, ok := files[cfgPath]
+	if !ok {
+		return true
+	}
 
-		atomic.StoreUint32(&isLoaded, 1)
+	fsys := fileSys.Load().(fileSystem)
+	for file, modTime := range files {
+		info, err := fsys.stat(file)
+		if err != nil || info.ModTime().UnixNano() != modTime {
+			return true
+		}
+	}
 
-		mx := &sync.Mutex{}
-		for _, callback := range refreshers.Load().([]func()) {
-			mx.Lock()
-			callback()
-			mx.Unlock()
+	for pattern, matches := range globs.Load().(map[string][]string) {
+		current, err := fsys.glob(pattern)
+		if err != nil || strings.Join(current, "\n") != strings.Join(matches, "\n") {
+			return true
 		}
 	}
 
+	return false
+}
+
+// decodeJson keeps numbers as json.Number to get integers without loss of precision
+func decodeJson(bs []byte) (obj Object, err error) {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+
+	err = dec.Decode(&obj)
+
 	return
 }
 
 // Debug sets logger for debug
 func Debug(callback func(message string)) {
 	l := logger.Load().(logFn)
-	l.debug = callback
+	l.debug = func(message string, attrs ...slog.Attr) {
+		callback(message)
+	}
 	logger.Store(l)
 
 	logger.Load().(logFn).debug("Set custom debug logger")
@@ -162,7 +206,9 @@ func Debug(callback func(message string)) {
 // Info sets logger for into
 func Info(callback func(message string)) {
 	l := logger.Load().(logFn)
-	l.info = callback
+	l.info = func(message string, attrs ...slog.Attr) {
+		callback(message)
+	}
 	logger.Store(l)
 
 	logger.Load().(logFn).debug("Set custom info logger")
@@ -171,7 +217,9 @@ func Info(callback func(message string)) {
 // Warn sets logger for warning
 func Warn(callback func(message string)) {
 	l := logger.Load().(logFn)
-	l.warn = callback
+	l.warn = func(message string, attrs ...slog.Attr) {
+		callback(message)
+	}
 	logger.Store(l)
 
 	logger.Load().(logFn).debug("Set custom warning logger")
@@ -180,19 +228,44 @@ func Warn(callback func(message string)) {
 // Error sets logger for error
 func Error(callback func(message string)) {
 	l := logger.Load().(logFn)
-	l.error = callback
+	l.error = func(message string, attrs ...slog.Attr) {
+		callback(message)
+	}
 	logger.Store(l)
 
 	logger.Load().(logFn).debug("Set custom error logger")
 }
 
-// Refresh adds callback on refresh
-func Refresh(callback func()) {
-	r := refreshers.Load().([]func())
-	r = append(r, callback)
-	refreshers.Store(r)
+// Logger sets structured logger for all levels instead of the callbacks
+func Logger(l *slog.Logger) {
+	logAttrs := func(level slog.Level) func(message string, attrs ...slog.Attr) {
+		return func(message string, attrs ...slog.Attr) {
+			l.LogAttrs(context.Background(), level, message, attrs...)
+		}
+	}
+
+	logger.Store(logFn{
+		debug: logAttrs(slog.LevelDebug),
+		info:  logAttrs(slog.LevelInfo),
+		warn:  logAttrs(slog.LevelWarn),
+		error: logAttrs(slog.LevelError),
+	})
+
+	logger.Load().(logFn).debug("Set structured logger")
+}
+
+// LogHandler sets structured logger with the handler
+func LogHandler(h slog.Handler) {
+	Logger(slog.New(h))
+}
+
+// Refresh adds callback on refresh, the callback is run with panic recovery and the options (Timeout, Async)
+func Refresh(callback func(), options ...CallbackOption) *Handle {
+	h := addRefresher(callback, options)
 
 	logger.Load().(logFn).debug("Add callback on refresh")
+
+	return h
 }
 
 // Exist returns flag is value existed by path
@@ -202,19 +275,38 @@ func Exist(path string) bool {
 
 // String returns string value by path
 func String(path string) (val string) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = StringE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// StringE returns string value by path or error if the value isn't exist or has unexpected type
+func StringE(path string) (val string, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.String(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustString returns string value by path or panics if the value isn't exist or has unexpected type
+func MustString(path string) (val string) {
+	var err error
+	val, err = StringE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -230,19 +322,38 @@ func StringOrDefault(path, defVal string) (val string) {
 
 // Bool returns bool value by path
 func Bool(path string) (val bool) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = BoolE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// BoolE returns bool value by path or error if the value isn't exist or has unexpected type
+func BoolE(path string) (val bool, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Bool(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustBool returns bool value by path or panics if the value isn't exist or has unexpected type
+func MustBool(path string) (val bool) {
+	var err error
+	val, err = BoolE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -258,19 +369,38 @@ func BoolOrDefault(path string, defVal bool) (val bool) {
 
 // Int32 returns int32 value by path
 func Int32(path string) (val int32) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = Int32E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Int32E returns int32 value by path or error if the value isn't exist or has unexpected type
+func Int32E(path string) (val int32, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Int32(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustInt32 returns int32 value by path or panics if the value isn't exist or has unexpected type
+func MustInt32(path string) (val int32) {
+	var err error
+	val, err = Int32E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -286,19 +416,38 @@ func Int32OrDefault(path string, defVal int32) (val int32) {
 
 // UInt32 returns uint32 value by path
 func UInt32(path string) (val uint32) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = UInt32E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// UInt32E returns uint32 value by path or error if the value isn't exist or has unexpected type
+func UInt32E(path string) (val uint32, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.UInt32(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustUInt32 returns uint32 value by path or panics if the value isn't exist or has unexpected type
+func MustUInt32(path string) (val uint32) {
+	var err error
+	val, err = UInt32E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -314,19 +463,38 @@ func UInt32OrDefault(path string, defVal uint32) (val uint32) {
 
 // Int64 returns int64 value by path
 func Int64(path string) (val int64) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = Int64E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Int64E returns int64 value by path or error if the value isn't exist or has unexpected type
+func Int64E(path string) (val int64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Int64(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustInt64 returns int64 value by path or panics if the value isn't exist or has unexpected type
+func MustInt64(path string) (val int64) {
+	var err error
+	val, err = Int64E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -342,19 +510,38 @@ func Int64OrDefault(path string, defVal int64) (val int64) {
 
 // UInt64 returns uint64 value by path
 func UInt64(path string) (val uint64) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = UInt64E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// UInt64E returns uint64 value by path or error if the value isn't exist or has unexpected type
+func UInt64E(path string) (val uint64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.UInt64(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustUInt64 returns uint64 value by path or panics if the value isn't exist or has unexpected type
+func MustUInt64(path string) (val uint64) {
+	var err error
+	val, err = UInt64E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -370,19 +557,38 @@ func UInt64OrDefault(path string, defVal uint64) (val uint64) {
 
 // Float32 returns float32 value by path
 func Float32(path string) (val float32) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = Float32E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Float32E returns float32 value by path or error if the value isn't exist or has unexpected type
+func Float32E(path string) (val float32, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Float32(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustFloat32 returns float32 value by path or panics if the value isn't exist or has unexpected type
+func MustFloat32(path string) (val float32) {
+	var err error
+	val, err = Float32E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -398,19 +604,38 @@ func Float32OrDefault(path string, defVal float32) (val float32) {
 
 // Float64 returns float64 value by path
 func Float64(path string) (val float64) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = Float64E(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Float64E returns float64 value by path or error if the value isn't exist or has unexpected type
+func Float64E(path string) (val float64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Float64(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustFloat64 returns float64 value by path or panics if the value isn't exist or has unexpected type
+func MustFloat64(path string) (val float64) {
+	var err error
+	val, err = Float64E(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -426,19 +651,38 @@ func Float64OrDefault(path string, defVal float64) (val float64) {
 
 // List returns slice of strings value by path
 func List(path string) (val []string) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = ListE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// ListE returns slice of strings value by path or error if the value isn't exist or has unexpected type
+func ListE(path string) (val []string, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.List(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustList returns slice of strings value by path or panics if the value isn't exist or has unexpected type
+func MustList(path string) (val []string) {
+	var err error
+	val, err = ListE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -454,19 +698,38 @@ func ListOrDefault(path string, defVal []string) (val []string) {
 
 // Slice returns slice of interfaces value by path
 func Slice(path string) (val []interface{}) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = SliceE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// SliceE returns slice of interfaces value by path or error if the value isn't exist or has unexpected type
+func SliceE(path string) (val []interface{}, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Slice(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustSlice returns slice of interfaces value by path or panics if the value isn't exist or has unexpected type
+func MustSlice(path string) (val []interface{}) {
+	var err error
+	val, err = SliceE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -482,76 +745,782 @@ func SliceOrDefault(path string, defVal []interface{}) (val []interface{}) {
 
 // Map returns map value by path
 func Map(path string) (val map[string]interface{}) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
-
-	obj := cfg.Load().(Object)
-
 	var err error
-	val, err = obj.Map(path)
+	val, err = MapE(path)
 	if err != nil {
 		handleErr(path, err)
-
-		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	return
+}
+
+// MapE returns map value by path or error if the value isn't exist or has unexpected type
+func MapE(path string) (val map[string]interface{}, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Map(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustMap returns map value by path or panics if the value isn't exist or has unexpected type
+func MustMap(path string) (val map[string]interface{}) {
+	var err error
+	val, err = MapE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// MapOrDefault returns map by path or default value
+func MapOrDefault(path string, defVal map[string]interface{}) (val map[string]interface{}) {
+	if Exist(path) {
+		return Map(path)
+	} else {
+		return defVal
+	}
+}
+
+// Duration returns duration value by path (bare numbers are in the unit or in DurationUnit by default)
+func Duration(path string, unit ...time.Duration) (val time.Duration) {
+	var err error
+	val, err = DurationE(path, unit...)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// DurationE returns duration value by path or error if the value isn't exist or has unexpected type
+func DurationE(path string, unit ...time.Duration) (val time.Duration, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Duration(path, unit...)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustDuration returns duration value by path or panics if the value isn't exist or has unexpected type
+func MustDuration(path string, unit ...time.Duration) (val time.Duration) {
+	var err error
+	val, err = DurationE(path, unit...)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// DurationOrDefault returns duration value by path or default value
+func DurationOrDefault(path string, defVal time.Duration, unit ...time.Duration) (val time.Duration) {
+	if Exist(path) {
+		return Duration(path, unit...)
+	} else {
+		return defVal
+	}
+}
+
+// DurationUnit sets unit of bare numbers in duration values (time.Second by default)
+func DurationUnit(unit time.Duration) {
+	atomic.StoreInt64(&durationUnit, int64(unit))
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Set duration unit %v", unit))
+}
+
+// Bytes returns byte size value by path ("64MB", "1GiB" or bare number of bytes)
+func Bytes(path string) (val uint64) {
+	var err error
+	val, err = BytesE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// BytesE returns byte size value by path or error if the value isn't exist or has unexpected type
+func BytesE(path string) (val uint64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Bytes(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustBytes returns byte size value by path or panics if the value isn't exist or has unexpected type
+func MustBytes(path string) (val uint64) {
+	var err error
+	val, err = BytesE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// BytesOrDefault returns byte size value by path or default value
+func BytesOrDefault(path string, defVal uint64) (val uint64) {
+	if Exist(path) {
+		return Bytes(path)
+	} else {
+		return defVal
+	}
+}
+
+// Time returns time value (RFC 3339 or one of the layouts) by path
+func Time(path string, layout ...string) (val time.Time) {
+	var err error
+	val, err = TimeE(path, layout...)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// TimeE returns time value (RFC 3339 or one of the layouts) by path or error if the value isn't exist or has unexpected type
+func TimeE(path string, layout ...string) (val time.Time, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Time(path, layout...)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustTime returns time value (RFC 3339 or one of the layouts) by path or panics if the value isn't exist or has unexpected type
+func MustTime(path string, layout ...string) (val time.Time) {
+	var err error
+	val, err = TimeE(path, layout...)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// TimeOrDefault returns time value (RFC 3339 or one of the layouts) by path or default value
+func TimeOrDefault(path string, defVal time.Time, layout ...string) (val time.Time) {
+	if Exist(path) {
+		return Time(path, layout...)
+	} else {
+		return defVal
+	}
+}
+
+// Date returns date value (2006-01-02) by path
+func Date(path string) (val time.Time) {
+	var err error
+	val, err = DateE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// DateE returns date value (2006-01-02) by path or error if the value isn't exist or has unexpected type
+func DateE(path string) (val time.Time, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Date(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustDate returns date value (2006-01-02) by path or panics if the value isn't exist or has unexpected type
+func MustDate(path string) (val time.Time) {
+	var err error
+	val, err = DateE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// DateOrDefault returns date value (2006-01-02) by path or default value
+func DateOrDefault(path string, defVal time.Time) (val time.Time) {
+	if Exist(path) {
+		return Date(path)
+	} else {
+		return defVal
+	}
+}
+
+// Location returns time zone value by path
+func Location(path string) (val *time.Location) {
+	var err error
+	val, err = LocationE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// LocationE returns time zone value by path or error if the value isn't exist or has unexpected type
+func LocationE(path string) (val *time.Location, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Location(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustLocation returns time zone value by path or panics if the value isn't exist or has unexpected type
+func MustLocation(path string) (val *time.Location) {
+	var err error
+	val, err = LocationE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// LocationOrDefault returns time zone value by path or default value
+func LocationOrDefault(path string, defVal *time.Location) (val *time.Location) {
+	if Exist(path) {
+		return Location(path)
+	} else {
+		return defVal
+	}
+}
+
+// URL returns url value by path
+func URL(path string) (val *url.URL) {
+	var err error
+	val, err = URLE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// URLE returns url value by path or error if the value isn't exist or has unexpected type
+func URLE(path string) (val *url.URL, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.URL(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustURL returns url value by path or panics if the value isn't exist or has unexpected type
+func MustURL(path string) (val *url.URL) {
+	var err error
+	val, err = URLE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// URLOrDefault returns url value by path or default value
+func URLOrDefault(path string, defVal *url.URL) (val *url.URL) {
+	if Exist(path) {
+		return URL(path)
+	} else {
+		return defVal
+	}
+}
+
+// IP returns ip address value by path
+func IP(path string) (val net.IP) {
+	var err error
+	val, err = IPE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// IPE returns ip address value by path or error if the value isn't exist or has unexpected type
+func IPE(path string) (val net.IP, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.IP(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustIP returns ip address value by path or panics if the value isn't exist or has unexpected type
+func MustIP(path string) (val net.IP) {
+	var err error
+	val, err = IPE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// IPOrDefault returns ip address value by path or default value
+func IPOrDefault(path string, defVal net.IP) (val net.IP) {
+	if Exist(path) {
+		return IP(path)
+	} else {
+		return defVal
+	}
+}
+
+// IPNet returns cidr value by path
+func IPNet(path string) (val *net.IPNet) {
+	var err error
+	val, err = IPNetE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// IPNetE returns cidr value by path or error if the value isn't exist or has unexpected type
+func IPNetE(path string) (val *net.IPNet, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.IPNet(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustIPNet returns cidr value by path or panics if the value isn't exist or has unexpected type
+func MustIPNet(path string) (val *net.IPNet) {
+	var err error
+	val, err = IPNetE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
 
-// MapOrDefault returns map by path or default value
-func MapOrDefault(path string, defVal map[string]interface{}) (val map[string]interface{}) {
+// IPNetOrDefault returns cidr value by path or default value
+func IPNetOrDefault(path string, defVal *net.IPNet) (val *net.IPNet) {
 	if Exist(path) {
-		return Map(path)
+		return IPNet(path)
 	} else {
 		return defVal
 	}
 }
 
-// Duration returns duration value by path
-func Duration(path string) (val time.Duration) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+// HostPort returns host:port value by path
+func HostPort(path string) (val Address) {
+	var err error
+	val, err = HostPortE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// HostPortE returns host:port value by path or error if the value isn't exist or has unexpected type
+func HostPortE(path string) (val Address, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var (
-		i64 int64
-		err error
-	)
-	i64, err = obj.Int64(path)
+	val, err = obj.HostPort(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustHostPort returns host:port value by path or panics if the value isn't exist or has unexpected type
+func MustHostPort(path string) (val Address) {
+	var err error
+	val, err = HostPortE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// HostPortOrDefault returns host:port value by path or default value
+func HostPortOrDefault(path string, defVal Address) (val Address) {
+	if Exist(path) {
+		return HostPort(path)
+	} else {
+		return defVal
+	}
+}
+
+// Regexp returns regular expression value by path
+func Regexp(path string) (val *regexp.Regexp) {
+	var err error
+	val, err = RegexpE(path)
 	if err != nil {
 		handleErr(path, err)
+	}
+
+	return
+}
 
+// RegexpE returns regular expression value by path or error if the value isn't exist or has unexpected type
+func RegexpE(path string) (val *regexp.Regexp, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Regexp(path)
+	if err != nil {
 		return
 	}
 
-	val = time.Duration(i64) * time.Second
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	return
+}
+
+// MustRegexp returns regular expression value by path or panics if the value isn't exist or has unexpected type
+func MustRegexp(path string) (val *regexp.Regexp) {
+	var err error
+	val, err = RegexpE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
 
-// DurationOrDefault returns duration value by path or default value
-func DurationOrDefault(path string, defVal time.Duration) (val time.Duration) {
+// RegexpOrDefault returns regular expression value by path or default value
+func RegexpOrDefault(path string, defVal *regexp.Regexp) (val *regexp.Regexp) {
 	if Exist(path) {
-		return Duration(path)
+		return Regexp(path)
 	} else {
 		return defVal
 	}
 }
 
-// Path returns path value by path
-func Path(path string) (val string) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+// Int64List returns slice of int64 values by path
+func Int64List(path string) (val []int64) {
+	var err error
+	val, err = Int64ListE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Int64ListE returns slice of int64 values by path or error if the value isn't exist or has unexpected type
+func Int64ListE(path string) (val []int64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.Int64List(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustInt64List returns slice of int64 values by path or panics if the value isn't exist or has unexpected type
+func MustInt64List(path string) (val []int64) {
+	var err error
+	val, err = Int64ListE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// Int64ListOrDefault returns slice of int64 values by path or default value
+func Int64ListOrDefault(path string, defVal []int64) (val []int64) {
+	if Exist(path) {
+		return Int64List(path)
+	} else {
+		return defVal
+	}
+}
+
+// Float64List returns slice of float64 values by path
+func Float64List(path string) (val []float64) {
+	var err error
+	val, err = Float64ListE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// Float64ListE returns slice of float64 values by path or error if the value isn't exist or has unexpected type
+func Float64ListE(path string) (val []float64, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
+	val, err = obj.Float64List(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustFloat64List returns slice of float64 values by path or panics if the value isn't exist or has unexpected type
+func MustFloat64List(path string) (val []float64) {
 	var err error
-	val, err = obj.String(path)
+	val, err = Float64ListE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// Float64ListOrDefault returns slice of float64 values by path or default value
+func Float64ListOrDefault(path string, defVal []float64) (val []float64) {
+	if Exist(path) {
+		return Float64List(path)
+	} else {
+		return defVal
+	}
+}
+
+// BoolList returns slice of bool values by path
+func BoolList(path string) (val []bool) {
+	var err error
+	val, err = BoolListE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// BoolListE returns slice of bool values by path or error if the value isn't exist or has unexpected type
+func BoolListE(path string) (val []bool, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.BoolList(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustBoolList returns slice of bool values by path or panics if the value isn't exist or has unexpected type
+func MustBoolList(path string) (val []bool) {
+	var err error
+	val, err = BoolListE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// BoolListOrDefault returns slice of bool values by path or default value
+func BoolListOrDefault(path string, defVal []bool) (val []bool) {
+	if Exist(path) {
+		return BoolList(path)
+	} else {
+		return defVal
+	}
+}
+
+// DurationList returns slice of duration values by path
+func DurationList(path string, unit ...time.Duration) (val []time.Duration) {
+	var err error
+	val, err = DurationListE(path, unit...)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// DurationListE returns slice of duration values by path or error if the value isn't exist or has unexpected type
+func DurationListE(path string, unit ...time.Duration) (val []time.Duration, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.DurationList(path, unit...)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustDurationList returns slice of duration values by path or panics if the value isn't exist or has unexpected type
+func MustDurationList(path string, unit ...time.Duration) (val []time.Duration) {
+	var err error
+	val, err = DurationListE(path, unit...)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// DurationListOrDefault returns slice of duration values by path or default value
+func DurationListOrDefault(path string, defVal []time.Duration, unit ...time.Duration) (val []time.Duration) {
+	if Exist(path) {
+		return DurationList(path, unit...)
+	} else {
+		return defVal
+	}
+}
+
+// ObjectList returns slice of objects by path
+func ObjectList(path string) (val []Object) {
+	var err error
+	val, err = ObjectListE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// ObjectListE returns slice of objects by path or error if the value isn't exist or has unexpected type
+func ObjectListE(path string) (val []Object, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
+
+	val, err = obj.ObjectList(path)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustObjectList returns slice of objects by path or panics if the value isn't exist or has unexpected type
+func MustObjectList(path string) (val []Object) {
+	var err error
+	val, err = ObjectListE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
+
+	return
+}
+
+// ObjectListOrDefault returns slice of objects by path or default value
+func ObjectListOrDefault(path string, defVal []Object) (val []Object) {
+	if Exist(path) {
+		return ObjectList(path)
+	} else {
+		return defVal
+	}
+}
+
+// Path returns path value by path
+func Path(path string) (val string) {
+	var err error
+	val, err = PathE(path)
 	if err != nil {
 		handleErr(path, err)
+	}
+
+	return
+}
+
+// PathE returns path value by path or error if the value isn't exist or has unexpected type
+func PathE(path string) (val string, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
+
+	obj := cfg.Load().(Object)
 
+	val, err = obj.String(path)
+	if err != nil {
 		return
 	}
 
@@ -563,12 +1532,21 @@ func Path(path string) (val string) {
 		return
 	}
 
-	cfgPath := filePath.Load().(string)
-	cfgPath = filepath.Dir(cfgPath)
+	fsys := fileSys.Load().(fileSystem)
+	val = fsys.join(fsys.dir(filePath.Load().(string)), val)
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
 
-	val = filepath.Join(cfgPath, val)
+	return
+}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+// MustPath returns path value by path or panics if the value isn't exist or has unexpected type
+func MustPath(path string) (val string) {
+	var err error
+	val, err = PathE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -584,19 +1562,38 @@ func PathOrDefault(path, defVal string) (val string) {
 
 // Interface returns interface value by path
 func Interface(path string) (val interface{}) {
-	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path))
+	var err error
+	val, err = InterfaceE(path)
+	if err != nil {
+		handleErr(path, err)
+	}
+
+	return
+}
+
+// InterfaceE returns interface value by path or error if the value isn't exist
+func InterfaceE(path string) (val interface{}, err error) {
+	logger.Load().(logFn).debug(fmt.Sprintf("Try to get value by %s", path), slog.String("path", path))
 
 	obj := cfg.Load().(Object)
 
-	var err error
 	val, err = obj.Interface(path)
 	if err != nil {
-		handleErr(path, err)
-
 		return
 	}
 
-	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, val))
+	logger.Load().(logFn).debug(fmt.Sprintf("Value by path `%s` is exist and is set `%v`", path, Redact(path, val)), valueAttrs(path, val)...)
+
+	return
+}
+
+// MustInterface returns interface value by path or panics if the value isn't exist
+func MustInterface(path string) (val interface{}) {
+	var err error
+	val, err = InterfaceE(path)
+	if err != nil {
+		handlePanic(path, err)
+	}
 
 	return
 }
@@ -613,10 +1610,32 @@ func InterfaceOrDefault(path string, defVal interface{}) (val interface{}) {
 func handleErr(path string, err error) {
 	switch err.(type) {
 	case *ValueNotExist:
-		logger.Load().(logFn).warn(fmt.Sprintf("Value by path `%s` isn't exist", path))
+		logger.Load().(logFn).warn(fmt.Sprintf("Value by path `%s` isn't exist", path), errAttrs(path, err)...)
 	case *ValueUnexpectedType:
-		logger.Load().(logFn).warn(fmt.Sprintf("Value by path `%s` contains unexpected type of value", path))
+		logger.Load().(logFn).warn(fmt.Sprintf("Value by path `%s` contains unexpected type of value", path), errAttrs(path, err)...)
 	default:
-		logger.Load().(logFn).error(fmt.Sprintf("Parsing by path `%s` returns error: %v", path, err))
+		logger.Load().(logFn).error(fmt.Sprintf("Parsing by path `%s` returns error: %v", path, err), errAttrs(path, err)...)
+	}
+}
+
+func handlePanic(path string, err error) {
+	logger.Load().(logFn).error(fmt.Sprintf("Can't get required value by path `%s`: %v", path, err), errAttrs(path, err)...)
+
+	panic(err)
+}
+
+func valueAttrs(path string, val interface{}) []slog.Attr {
+	return []slog.Attr{
+		slog.String("path", path),
+		slog.String("type", fmt.Sprintf("%T", val)),
+		slog.Any("value", Redact(path, val)),
+	}
+}
+
+func errAttrs(path string, err error) []slog.Attr {
+	return []slog.Attr{
+		slog.String("path", path),
+		slog.String("error_type", fmt.Sprintf("%T", err)),
+		slog.String("error", err.Error()),
 	}
 }
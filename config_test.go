@@ -1,7 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -178,3 +183,122 @@ func TestConfig(t *testing.T) {
 		t.Error("Can't get value by path `dur`")
 	}
 }
+
+func TestConfigStrict(t *testing.T) {
+	var v map[string]interface{}
+	err := json.Unmarshal(cfgBs, &v)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var o Object
+	o, err = Parse(v)
+	if err != nil {
+		t.Error(err)
+	}
+
+	InitAsStruct(o)
+
+	var i64 int64
+	i64, err = Int64E("int64")
+	if err != nil {
+		t.Error(err)
+	}
+	if i64 != -1 {
+		t.Error("Can't get value by path `int64`")
+	}
+
+	_, err = Int64E("port")
+	if _, ok := err.(*ValueNotExist); !ok {
+		t.Error("Int64E returns unexpected error for absent path")
+	}
+
+	_, err = Int64E("str")
+	if _, ok := err.(*ValueUnexpectedType); !ok {
+		t.Error("Int64E returns unexpected error for wrong type")
+	}
+
+	if MustString("str") != "text" {
+		t.Error("Can't get value by path `str`")
+	}
+
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Error("MustInt64 doesn't panic for absent path")
+
+				return
+			}
+
+			if _, ok := r.(*ValueNotExist); !ok {
+				t.Error("MustInt64 panics with unexpected value")
+			}
+		}()
+
+		MustInt64("port")
+	}()
+}
+
+func TestStructuredLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"port": 8080, "db": {"password": "s3cr3t"}}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	LogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	defer func() {
+		Debug(func(message string) {})
+		Info(func(message string) {})
+		Warn(func(message string) {})
+		Error(func(message string) {})
+	}()
+
+	err = Init(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	Int64("port")
+	String("db.password")
+	Int64("absent")
+
+	records := map[string]map[string]interface{}{}
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var record map[string]interface{}
+		err = json.Unmarshal(line, &record)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		records[record["msg"].(string)] = record
+	}
+
+	loaded, ok := records["Configuration is loaded"]
+	if !ok || loaded["file"] != cfgPath || loaded["generation"] == nil || loaded["duration"] == nil {
+		t.Errorf("Load record doesn't contain expected attributes: %v", loaded)
+	}
+
+	value, ok := records["Value by path `port` is exist and is set `8080`"]
+	if !ok || value["path"] != "port" || value["type"] != "int64" {
+		t.Errorf("Value record doesn't contain expected attributes: %v", value)
+	}
+
+	absent, ok := records["Value by path `absent` isn't exist"]
+	if !ok || absent["path"] != "absent" || absent["error_type"] != "*config.ValueNotExist" {
+		t.Errorf("Error record doesn't contain expected attributes: %v", absent)
+	}
+
+	if bytes.Contains(buf.Bytes(), []byte("s3cr3t")) {
+		t.Error("Structured log contains sensitive value")
+	}
+}
@@ -0,0 +1,407 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	encPrefix = "ENC[AES256_GCM,"
+	encSuffix = "]"
+)
+
+// KeyProvider returns 32-byte key to decrypt `ENC[AES256_GCM,...]` values
+type KeyProvider interface {
+	Key() (key []byte, err error)
+}
+
+// FileKeyProvider reads the key from the file by the path (raw 32 bytes or base64 text)
+type FileKeyProvider string
+
+func (p FileKeyProvider) Key() (key []byte, err error) {
+	var bs []byte
+	bs, err = ioutil.ReadFile(string(p))
+	if err != nil {
+		return
+	}
+
+	return parseKey(bs)
+}
+
+// EnvKeyProvider reads base64 key from the environment variable by the name
+type EnvKeyProvider string
+
+func (p EnvKeyProvider) Key() (key []byte, err error) {
+	str, ok := os.LookupEnv(string(p))
+	if !ok {
+		err = fmt.Errorf("environment variable %s isn't set", string(p))
+
+		return
+	}
+
+	return parseKey([]byte(str))
+}
+
+var keyProvider = &atomic.Value{}
+
+// SetKeyProvider sets provider of the key to decrypt encrypted values
+func SetKeyProvider(provider KeyProvider) {
+	keyProvider.Store(&provider)
+
+	logger.Load().(logFn).debug("Set key provider")
+}
+
+func parseKey(bs []byte) (key []byte, err error) {
+	if len(bs) == 32 {
+		return bs, nil
+	}
+
+	key, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(bs)))
+	if err != nil || len(key) != 32 {
+		key, err = nil, fmt.Errorf("key must be 32 bytes or base64 of 32 bytes")
+	}
+
+	return
+}
+
+// Encrypt encrypts the value (string, bool or number) into `ENC[AES256_GCM,data:...,iv:...,tag:...,type:...]`
+func Encrypt(val interface{}, key []byte) (enc string, err error) {
+	var plain, typ string
+	switch typed := val.(type) {
+	case string:
+		plain, typ = typed, "str"
+	case bool:
+		plain, typ = strconv.FormatBool(typed), "bool"
+	case json.Number:
+		plain, typ = typed.String(), "number"
+	case float64:
+		plain, typ = strconv.FormatFloat(typed, 'f', -1, 64), "number"
+	default:
+		err = fmt.Errorf("can't encrypt value of type %T", val)
+
+		return
+	}
+
+	var gcm cipher.AEAD
+	gcm, err = newGCM(key)
+	if err != nil {
+		return
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(iv)
+	if err != nil {
+		return
+	}
+
+	sealed := gcm.Seal(nil, iv, []byte(plain), nil)
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	enc = fmt.Sprintf("%sdata:%s,iv:%s,tag:%s,type:%s%s", encPrefix,
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		typ, encSuffix)
+
+	return
+}
+
+// Decrypt decrypts `ENC[AES256_GCM,...]` value into string, bool or json.Number
+func Decrypt(enc string, key []byte) (val interface{}, err error) {
+	if !IsEncrypted(enc) {
+		err = fmt.Errorf("value isn't encrypted")
+
+		return
+	}
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(enc, encPrefix), encSuffix), ",") {
+		i := strings.Index(field, ":")
+		if i == -1 {
+			err = fmt.Errorf("encrypted value contains wrong field `%s`", field)
+
+			return
+		}
+
+		fields[field[:i]] = field[i+1:]
+	}
+
+	var data, iv, tag []byte
+	for name, dst := range map[string]*[]byte{"data": &data, "iv": &iv, "tag": &tag} {
+		*dst, err = base64.StdEncoding.DecodeString(fields[name])
+		if err != nil {
+			err = fmt.Errorf("encrypted value contains wrong `%s`", name)
+
+			return
+		}
+	}
+
+	var gcm cipher.AEAD
+	gcm, err = newGCM(key)
+	if err != nil {
+		return
+	}
+
+	if len(iv) != gcm.NonceSize() {
+		err = fmt.Errorf("encrypted value contains wrong `iv`")
+
+		return
+	}
+
+	var plain []byte
+	plain, err = gcm.Open(nil, iv, append(data, tag...), nil)
+	if err != nil {
+		err = fmt.Errorf("can't decrypt value because of wrong key or damaged data")
+
+		return
+	}
+
+	switch fields["type"] {
+	case "str", "":
+		val = string(plain)
+	case "bool":
+		val, err = strconv.ParseBool(string(plain))
+	case "number":
+		val = json.Number(plain)
+	default:
+		err = fmt.Errorf("encrypted value contains unknown `type`")
+	}
+
+	return
+}
+
+// IsEncrypted checks the string is `ENC[AES256_GCM,...]` value
+func IsEncrypted(str string) bool {
+	return strings.HasPrefix(str, encPrefix) && strings.HasSuffix(str, encSuffix)
+}
+
+func newGCM(key []byte) (gcm cipher.AEAD, err error) {
+	if len(key) != 32 {
+		err = fmt.Errorf("key must be 32 bytes for AES256_GCM")
+
+		return
+	}
+
+	var block cipher.Block
+	block, err = aes.NewCipher(key)
+	if err != nil {
+		return
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// DecryptValues returns a copy of the object with decrypted `ENC[AES256_GCM,...]` values,
+// the key is requested from the key provider only if there are encrypted values
+func DecryptValues(obj Object) (res Object, err error) {
+	res, _, err = decryptPaths(obj)
+
+	return
+}
+
+// decryptPaths decrypts encrypted values and returns paths of decrypted values
+func decryptPaths(obj Object) (res Object, paths []string, err error) {
+	d := &decrypter{}
+
+	var v interface{}
+	v, err = d.walk(map[string]interface{}(obj), "")
+	if err != nil {
+		return
+	}
+
+	res, paths = v.(map[string]interface{}), d.paths
+
+	return
+}
+
+type decrypter struct {
+	key   []byte
+	paths []string
+}
+
+func (d *decrypter) walk(v interface{}, path string) (val interface{}, err error) {
+	switch typed := v.(type) {
+	case string:
+		if !IsEncrypted(typed) {
+			return typed, nil
+		}
+
+		if d.key == nil {
+			provider, ok := keyProvider.Load().(*KeyProvider)
+			if !ok || *provider == nil {
+				err = &UnresolvedValue{
+					message: fmt.Sprintf("path `%s` contains encrypted value but key provider isn't set", path),
+				}
+
+				return
+			}
+
+			d.key, err = (*provider).Key()
+			if err != nil {
+				err = &UnresolvedValue{
+					message: fmt.Sprintf("can't get key to decrypt values: %v", err),
+				}
+
+				return
+			}
+		}
+
+		d.paths = append(d.paths, path)
+
+		val, err = Decrypt(typed, d.key)
+		if err != nil {
+			err = &UnresolvedValue{
+				message: fmt.Sprintf("path `%s` contains encrypted value that can't be decrypted: %v", path, err),
+			}
+		}
+
+		return
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			array[i], err = d.walk(item, path)
+			if err != nil {
+				return
+			}
+		}
+
+		return array, nil
+	}
+
+	m, ok := asMap(v)
+	if !ok {
+		return v, nil
+	}
+
+	obj := make(map[string]interface{}, len(m))
+	for key, item := range m {
+		obj[key], err = d.walk(item, joinPath(path, key))
+		if err != nil {
+			return
+		}
+	}
+
+	return obj, nil
+}
+
+// EncryptFile encrypts the value by path inside the JSON file and rewrites the file atomically,
+// only the value is replaced so formatting and order of keys of the file are kept
+func EncryptFile(cfgPath, path string, key []byte) (err error) {
+	var bs []byte
+	bs, err = ioutil.ReadFile(cfgPath)
+	if err != nil {
+		return
+	}
+
+	var obj Object
+	obj, err = decodeJson(bs)
+	if err != nil {
+		err = fmt.Errorf("file %s isn't suported configuration", cfgPath)
+
+		return
+	}
+
+	var val interface{}
+	val, err = obj.Interface(path)
+	if err != nil {
+		return
+	}
+
+	if str, ok := val.(string); ok && IsEncrypted(str) {
+		return
+	}
+
+	var enc string
+	enc, err = Encrypt(val, key)
+	if err != nil {
+		return
+	}
+
+	var start, end int
+	start, end, err = valueSpan(bs, strings.Split(path, "."))
+	if err != nil {
+		err = fmt.Errorf("file %s isn't suported configuration", cfgPath)
+
+		return
+	}
+
+	var info os.FileInfo
+	info, err = os.Stat(cfgPath)
+	if err != nil {
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	buf.Write(bs[:start])
+	buf.WriteString(quote(enc))
+	buf.Write(bs[end:])
+
+	return writeFile(cfgPath, buf.Bytes(), info.Mode().Perm())
+}
+
+// valueSpan returns offsets of the value by keys inside the JSON document
+func valueSpan(bs []byte, keys []string) (start, end int, err error) {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+
+	for i, key := range keys {
+		var tok json.Token
+		tok, err = dec.Token()
+		if err != nil {
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+			err = fmt.Errorf("value by key `%s` isn't object", strings.Join(keys[:i], "."))
+
+			return
+		}
+
+		found := false
+		for dec.More() {
+			tok, err = dec.Token()
+			if err != nil {
+				return
+			}
+
+			if tok == key {
+				found = true
+
+				break
+			}
+
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+			if err != nil {
+				return
+			}
+		}
+
+		if !found {
+			err = fmt.Errorf("key `%s` isn't exist", strings.Join(keys[:i+1], "."))
+
+			return
+		}
+	}
+
+	start = int(dec.InputOffset())
+
+	var raw json.RawMessage
+	err = dec.Decode(&raw)
+	if err != nil {
+		return
+	}
+
+	end = int(dec.InputOffset())
+	start = end - len(raw)
+
+	return
+}
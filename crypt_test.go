@@ -0,0 +1,93 @@
+package config
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedValues(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"db": {"password": "s3cr3t", "port": 5432}, "debug": true,
+  "name":   "app"}`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"db.password", "db.port", "debug"} {
+		err = EncryptFile(cfgPath, path, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bs, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(bs), "s3cr3t") || strings.Count(string(bs), "ENC[AES256_GCM,") != 3 {
+		t.Error("Values aren't encrypted in the file")
+	}
+	if !strings.HasPrefix(string(bs), `{"db": {"password": "ENC[`) || !strings.HasSuffix(string(bs), ",\n  \"name\":   \"app\"}") {
+		t.Errorf("Formatting of the file isn't kept: %s", bs)
+	}
+
+	info, err := os.Stat(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Error("Permissions of the file aren't kept")
+	}
+
+	obj, err := decodeJson(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetKeyProvider(nil)
+
+	_, err = DecryptValues(obj)
+	if _, ok := err.(*UnresolvedValue); !ok {
+		t.Error("Absent key provider isn't reported")
+	}
+
+	err = os.Setenv("CONFIG_TEST_KEY", base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetKeyProvider(EnvKeyProvider("CONFIG_TEST_KEY"))
+
+	obj, err = DecryptValues(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str, _ := obj.String("db.password")
+	port, _ := obj.Int64("db.port")
+	debug, _ := obj.Bool("debug")
+	if str != "s3cr3t" || port != 5432 || !debug {
+		t.Error("Values aren't decrypted")
+	}
+
+	enc, err := Encrypt("text", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Decrypt(enc, []byte("fedcba9876543210fedcba9876543210"))
+	if err == nil {
+		t.Error("Value is decrypted with wrong key")
+	}
+}
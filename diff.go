@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ChangeType string
+
+const (
+	Added       ChangeType = "added"
+	Removed     ChangeType = "removed"
+	Modified    ChangeType = "modified"
+	TypeChanged ChangeType = "type changed"
+)
+
+// Change describes a difference by path, elements of arrays are addressed as `path[index]`
+type Change struct {
+	Type ChangeType
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// String returns the change with redacted sensitive values
+func (c Change) String() string {
+	path := c.Path
+	if i := strings.Index(path, "["); i != -1 {
+		path = path[:i]
+	}
+
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("%s `%s`: %v", c.Type, c.Path, Redact(path, c.New))
+	case Removed:
+		return fmt.Sprintf("%s `%s`: %v", c.Type, c.Path, Redact(path, c.Old))
+	default:
+		return fmt.Sprintf("%s `%s`: %v -> %v", c.Type, c.Path, Redact(path, c.Old), Redact(path, c.New))
+	}
+}
+
+// Diff returns added, removed and modified paths between two objects sorted by path;
+// maps and arrays are compared by keys and indexes, a change of the kind of the value
+// (e.g. map to string or number to string) is reported as TypeChanged without descending
+func Diff(a, b Object) (changes []Change) {
+	changes = diff("", map[string]interface{}(a), map[string]interface{}(b), changes)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return
+}
+
+func diff(path string, a, b interface{}, changes []Change) []Change {
+	kindA, kindB := kind(a), kind(b)
+	if kindA != kindB {
+		return append(changes, Change{Type: TypeChanged, Path: path, Old: a, New: b})
+	}
+
+	switch kindA {
+	case "object":
+		mapA, _ := asMap(a)
+		mapB, _ := asMap(b)
+
+		for key, valA := range mapA {
+			valB, ok := mapB[key]
+			if !ok {
+				changes = append(changes, Change{Type: Removed, Path: joinPath(path, key), Old: valA})
+
+				continue
+			}
+
+			changes = diff(joinPath(path, key), valA, valB, changes)
+		}
+
+		for key, valB := range mapB {
+			_, ok := mapA[key]
+			if !ok {
+				changes = append(changes, Change{Type: Added, Path: joinPath(path, key), New: valB})
+			}
+		}
+	case "array":
+		arrayA, arrayB := a.([]interface{}), b.([]interface{})
+
+		for i := 0; i < len(arrayA) || i < len(arrayB); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+
+			switch {
+			case i >= len(arrayB):
+				changes = append(changes, Change{Type: Removed, Path: itemPath, Old: arrayA[i]})
+			case i >= len(arrayA):
+				changes = append(changes, Change{Type: Added, Path: itemPath, New: arrayB[i]})
+			default:
+				changes = diff(itemPath, arrayA[i], arrayB[i], changes)
+			}
+		}
+	case "number":
+		strA, _ := numeric(a)
+		strB, _ := numeric(b)
+		if strA == strB {
+			return changes
+		}
+
+		f64A, errA := strconv.ParseFloat(strA, 64)
+		f64B, errB := strconv.ParseFloat(strB, 64)
+		if errA == nil && errB == nil && f64A == f64B && strings.ContainsAny(strA+strB, ".eE") {
+			return changes
+		}
+
+		changes = append(changes, Change{Type: Modified, Path: path, Old: a, New: b})
+	default:
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, Change{Type: Modified, Path: path, Old: a, New: b})
+		}
+	}
+
+	return changes
+}
+
+func kind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	}
+
+	if _, ok := asMap(v); ok {
+		return "object"
+	}
+
+	if _, ok := numeric(v); ok {
+		return "number"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
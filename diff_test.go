@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a, err := decodeJson([]byte(`{"name": "app", "port": 80, "big": 9007199254740993, "ratio": 1.0,
+"hosts": ["a", "b", "c"], "db": {"host": "a", "password": "old"}, "limits": {"rps": 10}, "removed": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := decodeJson([]byte(`{"name": "app", "port": 81, "big": 9007199254740992, "ratio": 1,
+"hosts": ["a", "x"], "db": {"host": "a", "password": "new"}, "limits": "none", "added": [1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := Diff(a, b)
+
+	expected := []Change{
+		{Type: Added, Path: "added"},
+		{Type: Modified, Path: "big"},
+		{Type: Modified, Path: "db.password"},
+		{Type: Modified, Path: "hosts[1]"},
+		{Type: Removed, Path: "hosts[2]"},
+		{Type: TypeChanged, Path: "limits"},
+		{Type: Modified, Path: "port"},
+		{Type: Removed, Path: "removed"},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Diff returns unexpected changes: %v", changes)
+	}
+
+	for i, c := range changes {
+		if c.Type != expected[i].Type || c.Path != expected[i].Path {
+			t.Errorf("Diff returns unexpected change %v instead of %s `%s`", c, expected[i].Type, expected[i].Path)
+		}
+	}
+
+	if changes[6].Old != json.Number("80") || changes[6].New != json.Number("81") {
+		t.Error("Diff returns unexpected values")
+	}
+
+	if str := changes[2].String(); strings.Contains(str, "old") || strings.Contains(str, "new") {
+		t.Errorf("Change contains sensitive values: %s", str)
+	}
+
+	if len(Diff(a, a)) != 0 {
+		t.Error("Diff returns changes for equal objects")
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var isoDurationRe = regexp.MustCompile(`^([-+])?P(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$`)
+
+// parseDuration parses a bare number in the unit, a Go duration string ("1m30s") or an ISO-8601 duration ("PT5M")
+func parseDuration(str string, unit time.Duration) (dur time.Duration, ok bool) {
+	str = strings.TrimSpace(str)
+
+	i64, err := strconv.ParseInt(str, 10, 64)
+	if err == nil {
+		if unit != 0 && (i64 > math.MaxInt64/int64(unit) || i64 < math.MinInt64/int64(unit)) {
+			return
+		}
+
+		return time.Duration(i64) * unit, true
+	}
+
+	f64, err := strconv.ParseFloat(str, 64)
+	if err == nil {
+		return fromFloat(f64 * float64(unit))
+	}
+
+	dur, err = time.ParseDuration(str)
+	if err == nil {
+		return dur, true
+	}
+
+	return parseISODuration(str)
+}
+
+// parseISODuration parses ISO-8601 durations with weeks, days, hours, minutes and seconds;
+// years and months are rejected because they haven't fixed length
+func parseISODuration(str string) (dur time.Duration, ok bool) {
+	matches := isoDurationRe.FindStringSubmatch(strings.ToUpper(str))
+	if matches == nil || strings.HasSuffix(str, "T") || strings.HasSuffix(str, "t") {
+		return
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var (
+		total float64
+		found bool
+	)
+	for i, unit := range units {
+		part := matches[i+2]
+		if part == "" {
+			continue
+		}
+
+		f64, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return
+		}
+
+		total += f64 * float64(unit)
+		found = true
+	}
+
+	if !found {
+		return
+	}
+
+	if matches[1] == "-" {
+		total = -total
+	}
+
+	return fromFloat(total)
+}
+
+func fromFloat(f64 float64) (dur time.Duration, ok bool) {
+	if math.IsNaN(f64) || f64 > math.MaxInt64 || f64 < math.MinInt64 {
+		return
+	}
+
+	return time.Duration(f64), true
+}
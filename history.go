@@ -0,0 +1,152 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Revision is an accepted configuration kept in the history
+type Revision struct {
+	Generation uint64
+	Time       time.Time
+	Hash       string
+	Source     string
+	Object     Object
+
+	sensitive []string
+	derived   []string
+}
+
+var (
+	history     = &atomic.Value{}
+	historySize = int64(10)
+)
+
+func init() {
+	history.Store([]Revision{})
+}
+
+// HistorySize sets number of accepted configurations kept in the history (10 by default)
+func HistorySize(size int) {
+	atomic.StoreInt64(&historySize, int64(size))
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Set history size %d", size))
+}
+
+// History returns copies of accepted configurations from the oldest to the newest one
+func History() []Revision {
+	list := append([]Revision{}, history.Load().([]Revision)...)
+	for i := range list {
+		list[i].Object = copyValue(list[i].Object).(Object)
+	}
+
+	return list
+}
+
+// Rollback makes the configuration of the generation active again as a new generation,
+// commit hooks are applied like on reload
+func Rollback(gen uint64) (err error) {
+	defer notifyCommitted()
+
+	loadMx.Lock()
+	defer loadMx.Unlock()
+
+	var (
+		rev   Revision
+		found bool
+	)
+	for _, r := range history.Load().([]Revision) {
+		if r.Generation == gen {
+			rev, found = r, true
+		}
+	}
+
+	if !found {
+		err = fmt.Errorf("generation %d isn't in the history", gen)
+
+		return
+	}
+
+	var newGen uint64
+	newGen, err = commit(copyValue(rev.Object).(Object), rev.Source, rev.sensitive, rev.derived)
+	if err != nil {
+		return
+	}
+
+	logger.Load().(logFn).info(fmt.Sprintf("Configuration is rolled back to generation %d", gen),
+		slog.Uint64("generation", newGen),
+		slog.Uint64("rollback_generation", gen),
+		slog.String("hash", rev.Hash))
+
+	return
+}
+
+func remember(gen uint64, obj Object, src string, sensitive, derived []string) {
+	rev := Revision{
+		Generation: gen,
+		Time:       time.Now(),
+		Hash:       hash(obj),
+		Source:     src,
+		Object:     obj,
+		sensitive:  sensitive,
+		derived:    derived,
+	}
+
+	list := append(append([]Revision{}, history.Load().([]Revision)...), rev)
+
+	size := int(atomic.LoadInt64(&historySize))
+	if size < 1 {
+		size = 1
+	}
+	if len(list) > size {
+		list = list[len(list)-size:]
+	}
+
+	history.Store(list)
+}
+
+// copyValue returns deep copy of objects and arrays of the value, so the copy can be changed without the active configuration
+func copyValue(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case Object:
+		obj := make(Object, len(typed))
+		for key, item := range typed {
+			obj[key] = copyValue(item)
+		}
+
+		return obj
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			obj[key] = copyValue(item)
+		}
+
+		return obj
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			array[i] = copyValue(item)
+		}
+
+		return array
+	}
+
+	return v
+}
+
+// hash returns sha256 of the object serialized with sorted keys
+func hash(obj Object) string {
+	bs, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(bs)
+
+	return hex.EncodeToString(sum[:])
+}
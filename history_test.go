@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	HistorySize(3)
+	defer HistorySize(10)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	for i := 1; i <= 4; i++ {
+		modTime := time.Now().Add(time.Duration(i) * time.Hour)
+		err = ioutil.WriteFile(cfgPath, []byte(fmt.Sprintf(`{"version": %d}`, i)), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(cfgPath, modTime, modTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 {
+			err = Init(cfgPath)
+		} else {
+			err = refreshJson()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer InitAsStruct(Object{})
+
+	revisions := History()
+	if len(revisions) != 3 {
+		t.Fatalf("History contains unexpected number of revisions: %d", len(revisions))
+	}
+
+	first := revisions[0]
+	version, _ := first.Object.Int64("version")
+	if version != 2 || first.Source != cfgPath || len(first.Hash) != 64 || first.Time.IsZero() {
+		t.Errorf("Revision contains unexpected values: %+v", first)
+	}
+
+	err = Rollback(first.Generation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 2 {
+		t.Error("Configuration isn't rolled back")
+	}
+
+	revisions = History()
+	revisions[len(revisions)-1].Object["version"] = 5
+	if Int64("version") != 2 {
+		t.Error("Change of revision changes the active configuration")
+	}
+
+	revisions = History()
+	last := revisions[len(revisions)-1]
+	if last.Generation <= revisions[len(revisions)-2].Generation || last.Hash != first.Hash {
+		t.Error("Rollback isn't kept in the history as a new generation")
+	}
+
+	err = refreshJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 2 {
+		t.Error("Rolled back configuration is overwritten by unchanged file")
+	}
+
+	err = Rollback(first.Generation - 1)
+	if err == nil {
+		t.Error("Rollback to generation that isn't in the history doesn't return error")
+	}
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var includeKeys = []string{"$include", "@include"}
+
+type includer struct {
+	fsys  fileSystem
+	files map[string]int64
+	globs map[string][]string
+	stack []string
+	// directives are paths of include directives in the main file
+	directives []string
+}
+
+func newIncluder(fsys fileSystem) *includer {
+	return &includer{
+		fsys:  fsys,
+		files: map[string]int64{},
+		globs: map[string][]string{},
+	}
+}
+
+// load reads the file and splices all files included by `$include`/`@include` directives into it
+func (inc *includer) load(cfgPath string) (obj Object, err error) {
+	for _, p := range inc.stack {
+		if p == cfgPath {
+			cycle := append(append([]string{}, inc.stack...), cfgPath)
+
+			err = fmt.Errorf("include cycle %s", strings.Join(cycle, " -> "))
+
+			return
+		}
+	}
+
+	var info os.FileInfo
+	info, err = inc.fsys.stat(cfgPath)
+	if err != nil {
+		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	var bs []byte
+	bs, err = inc.fsys.readFile(cfgPath)
+	if err != nil {
+		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	obj, err = decodeJson(bs)
+	if err != nil {
+		err = fmt.Errorf("file %s isn't suported configuration", cfgPath)
+
+		return
+	}
+
+	inc.files[cfgPath] = info.ModTime().UnixNano()
+
+	inc.stack = append(inc.stack, cfgPath)
+	defer func() {
+		inc.stack = inc.stack[:len(inc.stack)-1]
+	}()
+
+	var v interface{}
+	v, err = inc.resolve(map[string]interface{}(obj), inc.fsys.dir(cfgPath), "")
+	if err != nil {
+		return
+	}
+
+	obj = v.(map[string]interface{})
+
+	return
+}
+
+func (inc *includer) resolve(v interface{}, dir, path string) (val interface{}, err error) {
+	switch typed := v.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i], err = inc.resolve(item, dir, path)
+			if err != nil {
+				return
+			}
+		}
+
+		return typed, nil
+	case map[string]interface{}:
+		res := map[string]interface{}{}
+
+		for _, key := range includeKeys {
+			directive, ok := typed[key]
+			if !ok {
+				continue
+			}
+
+			delete(typed, key)
+
+			if len(inc.stack) <= 1 {
+				inc.directives = append(inc.directives, joinPath(path, key))
+			}
+
+			var patterns []string
+			patterns, err = includePatterns(directive)
+			if err != nil {
+				return
+			}
+
+			for _, pattern := range patterns {
+				err = inc.include(res, pattern, dir)
+				if err != nil {
+					return
+				}
+			}
+		}
+
+		for key, item := range typed {
+			typed[key], err = inc.resolve(item, dir, joinPath(path, key))
+			if err != nil {
+				return
+			}
+		}
+
+		merge(res, typed)
+
+		return res, nil
+	}
+
+	return v, nil
+}
+
+func (inc *includer) include(dst map[string]interface{}, pattern, dir string) (err error) {
+	if !inc.fsys.isAbs(pattern) {
+		pattern = inc.fsys.join(dir, pattern)
+	}
+
+	var matches []string
+	matches, err = inc.fsys.glob(pattern)
+	if err != nil {
+		err = fmt.Errorf("can't include %s because: %s", pattern, err.Error())
+
+		return
+	}
+
+	if strings.ContainsAny(pattern, "*?[") {
+		inc.globs[pattern] = matches
+	} else if len(matches) == 0 {
+		matches = []string{pattern}
+	}
+
+	var obj Object
+	for _, match := range matches {
+		obj, err = inc.load(match)
+		if err != nil {
+			return
+		}
+
+		merge(dst, obj)
+	}
+
+	return
+}
+
+func includePatterns(directive interface{}) (patterns []string, err error) {
+	switch typed := directive.(type) {
+	case string:
+		patterns = []string{typed}
+	case []interface{}:
+		for _, item := range typed {
+			str, ok := item.(string)
+			if !ok {
+				err = fmt.Errorf("include directive contains unexpected value `%v`", item)
+
+				return
+			}
+
+			patterns = append(patterns, str)
+		}
+	default:
+		err = fmt.Errorf("include directive contains unexpected value `%v`", directive)
+	}
+
+	return
+}
+
+// merge deeply copies src into dst, values of src override values of dst
+func merge(dst, src map[string]interface{}) {
+	for key, val := range src {
+		srcMap, ok := asMap(val)
+		if ok {
+			dstMap, ok := asMap(dst[key])
+			if ok {
+				merge(dstMap, srcMap)
+
+				continue
+			}
+		}
+
+		dst[key] = val
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"main.json":         `{"$include": "db.json", "app": {"@include": ["conf.d/*.json"], "name": "main"}}`,
+		"db.json":           `{"db": {"host": "localhost", "port": 5432}}`,
+		"conf.d/one.json":   `{"name": "one", "one": 1}`,
+		"conf.d/two.json":   `{"two": 2, "dsn": "${db.host}"}`,
+		"cycle/a.json":      `{"$include": "b.json"}`,
+		"cycle/b.json":      `{"$include": "a.json"}`,
+		"missing/main.json": `{"$include": "absent.json"}`,
+	}
+	for name, content := range files {
+		err = os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = Init(filepath.Join(dir, "main.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if String("db.host") != "localhost" || Int64("db.port") != 5432 {
+		t.Error("Included file isn't spliced into the root")
+	}
+
+	if String("app.name") != "main" || Int64("app.one") != 1 || Int64("app.two") != 2 {
+		t.Error("Included files aren't spliced into the subtree")
+	}
+
+	if String("app.dsn") != "localhost" {
+		t.Error("Included values aren't interpolated")
+	}
+
+	if Exist("$include") || Exist("app.@include") {
+		t.Error("Include directives are kept in the configuration")
+	}
+
+	future := time.Now().Add(time.Hour)
+	err = ioutil.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"db": {"host": "db.local"}}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chtimes(filepath.Join(dir, "db.json"), future, future)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = refreshJson()
+	if err != nil {
+		t.Error(err)
+	}
+	if String("db.host") != "db.local" {
+		t.Error("Changes of included file aren't reloaded")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "conf.d/three.json"), []byte(`{"three": 3}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = refreshJson()
+	if err != nil {
+		t.Error(err)
+	}
+	if Int64("app.three") != 3 {
+		t.Error("New file matched by include glob isn't loaded")
+	}
+
+	err = os.Remove(filepath.Join(dir, "conf.d/three.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = refreshJson()
+	if err != nil {
+		t.Error(err)
+	}
+	if Exist("app.three") {
+		t.Error("Removed file matched by include glob isn't unloaded")
+	}
+
+	_, err = newIncluder(fileSystem{}).load(filepath.Join(dir, "cycle/a.json"))
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Include cycle isn't detected: %v", err)
+	}
+
+	_, err = newIncluder(fileSystem{}).load(filepath.Join(dir, "missing/main.json"))
+	if err == nil {
+		t.Error("Absent included file isn't reported")
+	}
+}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+type UnresolvedValue struct {
+	message string
+}
+
+func (e *UnresolvedValue) Error() string {
+	return e.message
+}
+
+type interpolator struct {
+	src       Object
+	resolved  map[string]interface{}
+	stack     []string
+	sensitive map[string]bool
+	tainted   []string
+	expanded  []string
+}
+
+// Interpolate returns a copy of the object with resolved placeholders inside string values:
+// ${env:VAR} is an environment variable, ${other.path} is a value by path (or an environment variable
+// if the path isn't exist), ${name:-default} falls back to the default value and $${ is a literal ${
+func Interpolate(obj Object) (res Object, err error) {
+	res, _, _, err = interpolatePaths(obj, nil)
+
+	return
+}
+
+// interpolatePaths resolves placeholders like Interpolate and returns paths of values
+// that embed a sensitive value (see IsSensitive) or a value by one of the sensitive paths
+// and paths of values that contain placeholders
+func interpolatePaths(obj Object, sensitive []string) (res Object, tainted, expanded []string, err error) {
+	in := &interpolator{
+		src:       obj,
+		resolved:  map[string]interface{}{},
+		sensitive: map[string]bool{},
+	}
+	for _, p := range sensitive {
+		in.sensitive[strings.ToLower(p)] = true
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res = Object{}
+	for _, key := range keys {
+		res[key], err = in.resolvePath(key)
+		if err != nil {
+			return
+		}
+	}
+
+	tainted, expanded = in.tainted, in.expanded
+
+	return
+}
+
+func (in *interpolator) resolvePath(path string) (val interface{}, err error) {
+	val, ok := in.resolved[path]
+	if ok {
+		return
+	}
+
+	for i, p := range in.stack {
+		if p == path {
+			cycle := append(append([]string{}, in.stack[i:]...), path)
+
+			err = &UnresolvedValue{
+				message: fmt.Sprintf("interpolation cycle %s", strings.Join(cycle, " -> ")),
+			}
+
+			return
+		}
+	}
+
+	val, err = in.src.Interface(path)
+	if err != nil {
+		return
+	}
+
+	in.stack = append(in.stack, path)
+	val, err = in.expand(val, path, true)
+	in.stack = in.stack[:len(in.stack)-1]
+	if err != nil {
+		return
+	}
+
+	in.resolved[path] = val
+
+	return
+}
+
+// expand resolves placeholders inside the value, addressable maps are resolved by path to share the cache
+func (in *interpolator) expand(v interface{}, path string, addressable bool) (val interface{}, err error) {
+	switch typed := v.(type) {
+	case string:
+		return in.expandString(typed, path)
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			array[i], err = in.expand(item, path, false)
+			if err != nil {
+				return
+			}
+		}
+
+		return array, nil
+	}
+
+	m, ok := asMap(v)
+	if !ok {
+		return v, nil
+	}
+
+	obj := make(map[string]interface{}, len(m))
+	for key, item := range m {
+		if addressable {
+			obj[key], err = in.resolvePath(path + "." + key)
+		} else {
+			obj[key], err = in.expand(item, path, false)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return obj, nil
+}
+
+func (in *interpolator) expandString(str, path string) (val interface{}, err error) {
+	if !strings.Contains(str, "${") {
+		return str, nil
+	}
+
+	in.expanded = append(in.expanded, path)
+
+	var (
+		sb   strings.Builder
+		rest = str
+		sub  interface{}
+	)
+	for {
+		i := strings.Index(rest, "${")
+		if i == -1 {
+			sb.WriteString(rest)
+
+			break
+		}
+
+		if i > 0 && rest[i-1] == '$' {
+			sb.WriteString(rest[:i-1])
+			sb.WriteString("${")
+			rest = rest[i+2:]
+
+			continue
+		}
+
+		j := strings.Index(rest[i:], "}")
+		if j == -1 {
+			var shown interface{} = str
+			if isSensitive(path, in.sensitive) {
+				shown = Redacted
+			}
+
+			err = &UnresolvedValue{
+				message: fmt.Sprintf("path `%s` contains unclosed placeholder in `%v`", path, shown),
+			}
+
+			return
+		}
+
+		sub, err = in.lookup(rest[i+2:i+j], path)
+		if err != nil {
+			return
+		}
+
+		if rest == str && i == 0 && j+1 == len(str) {
+			return sub, nil
+		}
+
+		sb.WriteString(rest[:i])
+		sb.WriteString(fmt.Sprintf("%v", sub))
+		rest = rest[i+j+1:]
+	}
+
+	return sb.String(), nil
+}
+
+// containsSensitive checks the value by path or one of its nested values is sensitive
+func (in *interpolator) containsSensitive(path string, v interface{}) bool {
+	if isSensitive(path, in.sensitive) {
+		return true
+	}
+
+	switch typed := v.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			if in.containsSensitive(path, item) {
+				return true
+			}
+		}
+	default:
+		m, _ := asMap(v)
+		for key, item := range m {
+			if in.containsSensitive(joinPath(path, key), item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (in *interpolator) taint(path string) {
+	if in.sensitive[strings.ToLower(path)] {
+		return
+	}
+
+	in.sensitive[strings.ToLower(path)] = true
+	in.tainted = append(in.tainted, path)
+}
+
+func (in *interpolator) lookup(expr, path string) (val interface{}, err error) {
+	name, defVal, hasDef := expr, "", false
+	if i := strings.Index(expr, ":-"); i != -1 {
+		name, defVal, hasDef = expr[:i], expr[i+2:], true
+	}
+
+	if strings.HasPrefix(name, "env:") {
+		env, ok := os.LookupEnv(strings.TrimPrefix(name, "env:"))
+		if ok {
+			return env, nil
+		}
+	} else if in.src.IsExist(name) {
+		val, err = in.resolvePath(name)
+		if err == nil && in.containsSensitive(name, val) {
+			in.taint(path)
+		}
+
+		return
+	} else if env, ok := os.LookupEnv(name); ok {
+		return env, nil
+	}
+
+	if hasDef {
+		return defVal, nil
+	}
+
+	err = &UnresolvedValue{
+		message: fmt.Sprintf("path `%s` contains unresolved placeholder `${%s}`", path, expr),
+	}
+
+	return
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	err := os.Setenv("CONFIG_TEST_HOST", "db.local")
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj, err := decodeJson([]byte(`{
+	"host": "${env:CONFIG_TEST_HOST}",
+	"port": 5432,
+	"db": {"dsn": "postgres://${host}:${port}/app", "port": "${port}", "user": "${CONFIG_TEST_USER:-admin}"},
+	"list": ["${db.user}", {"url": "${db.dsn}"}],
+	"literal": "$${host}"
+}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	obj, err = Interpolate(obj)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var str string
+	str, err = obj.String("db.dsn")
+	if err != nil {
+		t.Error(err)
+	}
+	if str != "postgres://db.local:5432/app" {
+		t.Errorf("Interpolation returns unexpected result `%s`", str)
+	}
+
+	var i64 int64
+	i64, err = obj.Int64("db.port")
+	if err != nil {
+		t.Error(err)
+	}
+	if i64 != 5432 {
+		t.Error("Interpolation doesn't keep type of the value")
+	}
+
+	str, _ = obj.String("db.user")
+	if str != "admin" {
+		t.Error("Interpolation doesn't use default value")
+	}
+
+	list, _ := obj.Slice("list")
+	if len(list) != 2 || list[0] != "admin" || list[1].(map[string]interface{})["url"] != "postgres://db.local:5432/app" {
+		t.Error("Interpolation doesn't resolve values inside lists")
+	}
+
+	str, _ = obj.String("literal")
+	if str != "${host}" {
+		t.Error("Interpolation doesn't unescape placeholders")
+	}
+
+	_, err = Interpolate(Object{"a": "${b}", "b": map[string]interface{}{"c": "${a}"}})
+	if err == nil || err.Error() != "interpolation cycle a -> b -> b.c -> a" {
+		t.Errorf("Interpolation returns unexpected error for cycle: %v", err)
+	}
+
+	_, err = Interpolate(Object{"a": "${absent.path}"})
+	if _, ok := err.(*UnresolvedValue); !ok {
+		t.Error("Interpolation returns unexpected error for unresolved placeholder")
+	}
+
+	_, err = Interpolate(Object{"db": map[string]interface{}{"password": "p@ss${"}})
+	if err == nil || strings.Contains(err.Error(), "p@ss") {
+		t.Errorf("Interpolation returns sensitive value in error: %v", err)
+	}
+}
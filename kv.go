@@ -0,0 +1,462 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// KVEvent is a change of the key in a key-value store
+type KVEvent struct {
+	Key     string
+	Value   string
+	Deleted bool
+}
+
+// KVSource is a key-value store (e.g. etcd or Consul) with keys like `app/db/host`
+type KVSource interface {
+	// List returns values of all keys with the prefix
+	List(ctx context.Context, prefix string) (values map[string]string, err error)
+	// Get returns value of the key, ok is false if the key isn't exist
+	Get(ctx context.Context, key string) (value string, ok bool, err error)
+	// Watch returns channel with changes of keys with the prefix, the channel is closed when the context is done;
+	// if the channel is closed before (e.g. changes can't be delivered), the prefix is watched and listed again
+	Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error)
+}
+
+type kvSource struct {
+	src    KVSource
+	prefix string
+	cache  string
+
+	mx     sync.Mutex
+	values map[string]string
+	loaded bool
+}
+
+// InitKV sets configuration from keys of the key-value store with the prefix and set refresh data from its by the changes stream;
+// `app/db/host` with the prefix `app/` is got by path `db.host`, values that are JSON objects or arrays are decoded and other values are kept as strings.
+// The store is listed again only by Reload or with PollInterval option.
+func InitKV(src KVSource, prefix string, options ...InitOption) (err error) {
+	opts := newInitOptions(options)
+	if opts.interval <= 0 {
+		opts.polling = false
+	}
+
+	k := &kvSource{
+		src:    src,
+		prefix: prefix,
+		cache:  opts.cache,
+	}
+
+	logger.Load().(logFn).info("Configuration is initialized", slog.String("prefix", prefix))
+
+	// the changes stream is subscribed before the keys are listed to not miss changes between them
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, watchErr := src.Watch(ctx, prefix)
+
+	err = k.update(false)
+	if err != nil {
+		err = fallback(opts.cache, prefix, err)
+		if err != nil {
+			cancel()
+
+			return
+		}
+	}
+
+	if watchErr != nil {
+		logger.Load().(logFn).warn(fmt.Sprintf("Changes of config by prefix %s can't be watched because: %s, the store is polled instead", prefix, watchErr.Error()),
+			slog.String("prefix", prefix))
+
+		if !opts.polling || opts.interval <= 0 {
+			opts.polling, opts.interval = true, 30*time.Second
+		}
+	}
+
+	atomic.StoreUint32(&withRefresh, 1)
+
+	fileSys.Store(fileSystem{})
+	filePath.Store("")
+
+	watch(opts, k.load, slog.String("prefix", prefix))
+
+	if watchErr != nil {
+		cancel()
+
+		return
+	}
+
+	onUnwatch(cancel)
+
+	go k.stream(ctx, events)
+
+	return
+}
+
+// load lists the keys and applies them if they are changed or if the load is forced
+func (k *kvSource) load(force bool) (err error) {
+	if atomic.LoadUint32(&withRefresh) != 1 {
+		return
+	}
+
+	return k.update(force)
+}
+
+// update lists the keys and applies them if they are changed or if the update is forced
+func (k *kvSource) update(force bool) (err error) {
+	defer notifyCommitted()
+
+	// the keys are listed under the lock, so the snapshot doesn't override changes applied while it's listed
+	k.mx.Lock()
+	defer k.mx.Unlock()
+
+	var values map[string]string
+	values, err = k.src.List(context.Background(), k.prefix)
+	if err != nil {
+		err = fmt.Errorf("can't load config by prefix %s because: %s", k.prefix, err.Error())
+
+		return
+	}
+
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	if !force && k.values != nil && equalValues(k.values, values) {
+		return
+	}
+
+	k.values = values
+
+	return k.apply()
+}
+
+// stream applies changes of the keys until the context is done; if the changes stream is closed before,
+// changes could be missed, so the prefix is watched again and the keys are listed again
+func (k *kvSource) stream(ctx context.Context, events <-chan KVEvent) {
+	for {
+		for event := range events {
+			if ctx.Err() != nil {
+				continue
+			}
+
+			err := k.change(event)
+			if err != nil {
+				logger.Load().(logFn).error(err.Error(),
+					slog.String("prefix", k.prefix),
+					slog.String("key", event.Key),
+					slog.String("error_type", fmt.Sprintf("%T", err)))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+
+		logger.Load().(logFn).warn(fmt.Sprintf("Changes stream of config by prefix %s is closed, the prefix is watched again", k.prefix),
+			slog.String("prefix", k.prefix))
+
+		var err error
+		events, err = k.src.Watch(ctx, k.prefix)
+		if err != nil {
+			logger.Load().(logFn).error(fmt.Sprintf("Can't watch config by prefix %s because: %s", k.prefix, err.Error()),
+				slog.String("prefix", k.prefix),
+				slog.String("error_type", fmt.Sprintf("%T", err)))
+
+			return
+		}
+
+		err = k.update(false)
+		if err != nil {
+			logger.Load().(logFn).error(err.Error(),
+				slog.String("prefix", k.prefix),
+				slog.String("error_type", fmt.Sprintf("%T", err)))
+
+			// the keys are listed again by the next change
+			k.mx.Lock()
+			k.values = nil
+			k.mx.Unlock()
+		}
+	}
+}
+
+// change applies the event to the listed values, if the keys aren't listed yet (the configuration is loaded from the cache)
+// they are listed instead because the event can't be applied to a partial set of values
+func (k *kvSource) change(event KVEvent) (err error) {
+	defer notifyCommitted()
+
+	k.mx.Lock()
+
+	if k.values == nil {
+		k.mx.Unlock()
+
+		return k.update(true)
+	}
+
+	defer k.mx.Unlock()
+
+	values := make(map[string]string, len(k.values)+1)
+	for key, val := range k.values {
+		values[key] = val
+	}
+
+	if event.Deleted {
+		delete(values, event.Key)
+	} else {
+		values[event.Key] = event.Value
+	}
+
+	k.values = values
+
+	return k.apply()
+}
+
+// apply commits object built from the values
+func (k *kvSource) apply() (err error) {
+	loadMx.Lock()
+	defer loadMx.Unlock()
+
+	start := time.Now()
+
+	obj, conflicts := kvObject(k.prefix, k.values)
+	for _, key := range conflicts {
+		logger.Load().(logFn).warn(fmt.Sprintf("Value of key %s is overridden by nested keys", key),
+			slog.String("prefix", k.prefix),
+			slog.String("key", key))
+	}
+
+	var raw []byte
+	raw, err = json.Marshal(obj)
+	if err != nil {
+		err = fmt.Errorf("can't load config by prefix %s because: %s", k.prefix, err.Error())
+
+		return
+	}
+
+	var sensitive, derived []string
+	obj, sensitive, derived, err = prepare(obj)
+	if err != nil {
+		err = fmt.Errorf("can't load config by prefix %s because: %s", k.prefix, err.Error())
+
+		return
+	}
+
+	var gen uint64
+	gen, err = commit(obj, k.prefix, sensitive, derived)
+	if err != nil {
+		err = fmt.Errorf("can't load config by prefix %s because: %s", k.prefix, err.Error())
+
+		return
+	}
+
+	accept(k.cache, k.prefix, raw)
+
+	attrs := []slog.Attr{
+		slog.String("prefix", k.prefix),
+		slog.Uint64("generation", gen),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if !k.loaded {
+		logger.Load().(logFn).info("Configuration is loaded", attrs...)
+	} else {
+		logger.Load().(logFn).info("Configuration is reloaded", attrs...)
+	}
+
+	k.loaded = true
+
+	return
+}
+
+// kvObject maps keys like `db/host` into nested object, values of keys that also have nested keys are returned as conflicts
+func kvObject(prefix string, values map[string]string) (obj Object, conflicts []string) {
+	obj = Object{}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var segments []string
+		for _, segment := range strings.Split(strings.TrimPrefix(key, prefix), "/") {
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+
+		if len(segments) == 0 {
+			continue
+		}
+
+		node := map[string]interface{}(obj)
+		for i, segment := range segments[:len(segments)-1] {
+			next, ok := node[segment].(map[string]interface{})
+			if !ok {
+				if _, exist := node[segment]; exist {
+					conflicts = append(conflicts, prefix+strings.Join(segments[:i+1], "/"))
+				}
+
+				next = map[string]interface{}{}
+				node[segment] = next
+			}
+
+			node = next
+		}
+
+		last := segments[len(segments)-1]
+		if _, ok := node[last].(map[string]interface{}); ok {
+			conflicts = append(conflicts, key)
+
+			continue
+		}
+
+		node[last] = kvValue(values[key])
+	}
+
+	return
+}
+
+// kvValue decodes JSON objects and arrays, other values are kept as strings
+func kvValue(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var v interface{}
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		dec.UseNumber()
+
+		if dec.Decode(&v) == nil && !dec.More() {
+			return v
+		}
+	}
+
+	return value
+}
+
+func equalValues(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, val := range a {
+		other, ok := b[key]
+		if !ok || other != val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MemoryKV is in-memory KVSource for tests
+type MemoryKV struct {
+	mx       sync.Mutex
+	values   map[string]string
+	watchers map[chan KVEvent]string
+}
+
+// NewMemoryKV returns in-memory KVSource with the values
+func NewMemoryKV(values map[string]string) *MemoryKV {
+	kv := &MemoryKV{
+		values:   map[string]string{},
+		watchers: map[chan KVEvent]string{},
+	}
+	for key, val := range values {
+		kv.values[key] = val
+	}
+
+	return kv
+}
+
+// List returns values of all keys with the prefix
+func (kv *MemoryKV) List(ctx context.Context, prefix string) (values map[string]string, err error) {
+	kv.mx.Lock()
+	defer kv.mx.Unlock()
+
+	values = map[string]string{}
+	for key, val := range kv.values {
+		if strings.HasPrefix(key, prefix) {
+			values[key] = val
+		}
+	}
+
+	return
+}
+
+// Get returns value of the key
+func (kv *MemoryKV) Get(ctx context.Context, key string) (value string, ok bool, err error) {
+	kv.mx.Lock()
+	defer kv.mx.Unlock()
+
+	value, ok = kv.values[key]
+
+	return
+}
+
+// Watch returns channel with changes of keys with the prefix, the channel is closed if it's full on a change
+func (kv *MemoryKV) Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error) {
+	ch := make(chan KVEvent, WatchBuffer)
+
+	kv.mx.Lock()
+	kv.watchers[ch] = prefix
+	kv.mx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		kv.mx.Lock()
+		if _, ok := kv.watchers[ch]; ok {
+			delete(kv.watchers, ch)
+			close(ch)
+		}
+		kv.mx.Unlock()
+	}()
+
+	return ch, nil
+}
+
+// Put sets value of the key and notifies watchers
+func (kv *MemoryKV) Put(key, value string) {
+	kv.notify(KVEvent{Key: key, Value: value})
+}
+
+// Delete removes the key and notifies watchers
+func (kv *MemoryKV) Delete(key string) {
+	kv.notify(KVEvent{Key: key, Deleted: true})
+}
+
+func (kv *MemoryKV) notify(event KVEvent) {
+	kv.mx.Lock()
+	defer kv.mx.Unlock()
+
+	if event.Deleted {
+		delete(kv.values, event.Key)
+	} else {
+		kv.values[event.Key] = event.Value
+	}
+
+	// the lock is held, so a slow watcher doesn't block it: its channel is closed instead of losing the event
+	for ch, prefix := range kv.watchers {
+		if !strings.HasPrefix(event.Key, prefix) {
+			continue
+		}
+
+		select {
+		case ch <- event:
+		default:
+			delete(kv.watchers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInitKV(t *testing.T) {
+	kv := NewMemoryKV(map[string]string{
+		"app/db/host":    "localhost",
+		"app/db/port":    "5432",
+		"app/db/timeout": "5s",
+		"app/debug":      "true",
+		"app/hosts":      `["a", "b"]`,
+		"app/":           "",
+		"other/key":      "value",
+	})
+
+	err := InitKV(kv, "app/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if String("db.host") != "localhost" || Int64("db.port") != 5432 || Duration("db.timeout") != 5*time.Second {
+		t.Error("Configuration from key-value store contains unexpected values")
+	}
+	if !Bool("debug") || len(List("hosts")) != 2 || Exist("key") {
+		t.Error("Configuration from key-value store contains unexpected values")
+	}
+
+	changes := make(chan interface{}, 1)
+	h := OnChange("db.host", func(old, new interface{}) {
+		changes <- new
+	})
+	defer h.Unregister()
+
+	kv.Put("app/db/host", "db.local")
+
+	select {
+	case val := <-changes:
+		if val != "db.local" {
+			t.Errorf("Change of key contains unexpected value: %v", val)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Change of key isn't applied")
+	}
+
+	kv.Delete("app/db/host")
+
+	select {
+	case val := <-changes:
+		if val != nil {
+			t.Errorf("Deleted key contains unexpected value: %v", val)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Deletion of key isn't applied")
+	}
+
+	hook := BeforeCommit(func(candidate Object) error {
+		return errors.New("rejected")
+	})
+	err = Reload()
+	hook.Unregister()
+	if err == nil {
+		t.Error("Reload doesn't return error of commit hook")
+	}
+
+	err = InitKV(brokenKV{}, "app/")
+	if err == nil {
+		t.Error("Unreachable key-value store doesn't return error")
+	}
+
+	kv.Put("app/db/host", "db.remote")
+
+	err = Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if String("db.host") != "db.remote" {
+		t.Error("Failed initialization replaces the active source")
+	}
+}
+
+type brokenKV struct{}
+
+func (brokenKV) List(ctx context.Context, prefix string) (values map[string]string, err error) {
+	return nil, errors.New("unreachable")
+}
+
+func (brokenKV) Get(ctx context.Context, key string) (value string, ok bool, err error) {
+	return "", false, errors.New("unreachable")
+}
+
+func (brokenKV) Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error) {
+	return nil, errors.New("unreachable")
+}
+
+func TestKVObject(t *testing.T) {
+	obj, conflicts := kvObject("app/", map[string]string{
+		"app/db":         "scalar",
+		"app/db/host":    "localhost",
+		"app//log/level": "debug",
+	})
+
+	host, _ := obj.String("db.host")
+	level, _ := obj.String("log.level")
+	if host != "localhost" || level != "debug" {
+		t.Errorf("Object contains unexpected values: %v", obj)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "app/db" {
+		t.Errorf("Conflicts contain unexpected keys: %v", conflicts)
+	}
+}
+
+func TestMemoryKVNotify(t *testing.T) {
+	kv := NewMemoryKV(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := kv.Watch(ctx, "app/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i <= WatchBuffer; i++ {
+			kv.Put("app/key", "value")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Put is blocked by the full channel of watcher")
+	}
+
+	var count int
+	for range events {
+		count++
+	}
+	if count != WatchBuffer {
+		t.Errorf("Channel of watcher contains unexpected number of events: %d", count)
+	}
+}
+
+type orderedKV struct {
+	*MemoryKV
+	calls []string
+}
+
+func (kv *orderedKV) List(ctx context.Context, prefix string) (values map[string]string, err error) {
+	kv.calls = append(kv.calls, "list")
+
+	return kv.MemoryKV.List(ctx, prefix)
+}
+
+func (kv *orderedKV) Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error) {
+	kv.calls = append(kv.calls, "watch")
+
+	return kv.MemoryKV.Watch(ctx, prefix)
+}
+
+func TestInitKVWatchesBeforeList(t *testing.T) {
+	kv := &orderedKV{MemoryKV: NewMemoryKV(map[string]string{"app/key": "value"})}
+
+	err := InitKV(kv, "app/", NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if len(kv.calls) != 2 || kv.calls[0] != "watch" || kv.calls[1] != "list" {
+		t.Errorf("Key-value store is called in unexpected order: %v", kv.calls)
+	}
+}
+
+type unwatchableKV struct {
+	*MemoryKV
+}
+
+func (kv unwatchableKV) Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error) {
+	return nil, errors.New("watch isn't supported")
+}
+
+func TestInitKVWithoutWatch(t *testing.T) {
+	kv := unwatchableKV{MemoryKV: NewMemoryKV(map[string]string{"app/key": "value"})}
+
+	err := InitKV(kv, "app/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if IsStale() || String("key") != "value" {
+		t.Error("Keys aren't listed from store that can't be watched")
+	}
+}
+
+type closingKV struct {
+	*MemoryKV
+	events  chan KVEvent
+	watched int32
+}
+
+func (kv *closingKV) Watch(ctx context.Context, prefix string) (events <-chan KVEvent, err error) {
+	if atomic.AddInt32(&kv.watched, 1) == 1 {
+		return kv.events, nil
+	}
+
+	return kv.MemoryKV.Watch(ctx, prefix)
+}
+
+func TestInitKVResync(t *testing.T) {
+	kv := &closingKV{
+		MemoryKV: NewMemoryKV(map[string]string{"app/key": "value"}),
+		events:   make(chan KVEvent),
+	}
+
+	err := InitKV(kv, "app/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	kv.MemoryKV.mx.Lock()
+	kv.MemoryKV.values["app/key"] = "missed"
+	kv.MemoryKV.mx.Unlock()
+
+	close(kv.events)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for String("key") != "missed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Keys aren't listed again after the changes stream is closed")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	kv.Put("app/key", "changed")
+
+	deadline = time.Now().Add(5 * time.Second)
+	for String("key") != "changed" {
+		if time.Now().After(deadline) {
+			t.Fatal("Changes aren't applied after the prefix is watched again")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// Format is a format of serialized configuration, only JSON is supported to load configuration
+type Format string
+
+const (
+	JSON        Format = "json"
+	CompactJSON Format = "compact json"
+	YAML        Format = "yaml"
+	TOML        Format = "toml"
+)
+
+var fileSys = &atomic.Value{}
+
+func init() {
+	fileSys.Store(fileSystem{})
+
+	filePath.Store("")
+}
+
+// fileSystem reads config files from the file system or from fs.FS if it's set
+type fileSystem struct {
+	fsys fs.FS
+}
+
+func (f fileSystem) stat(name string) (fs.FileInfo, error) {
+	if f.fsys == nil {
+		return os.Stat(name)
+	}
+
+	return fs.Stat(f.fsys, name)
+}
+
+func (f fileSystem) readFile(name string) ([]byte, error) {
+	if f.fsys == nil {
+		return os.ReadFile(name)
+	}
+
+	return fs.ReadFile(f.fsys, name)
+}
+
+func (f fileSystem) glob(pattern string) ([]string, error) {
+	if f.fsys == nil {
+		return filepath.Glob(pattern)
+	}
+
+	return fs.Glob(f.fsys, pattern)
+}
+
+func (f fileSystem) isAbs(name string) bool {
+	if f.fsys == nil {
+		return filepath.IsAbs(name)
+	}
+
+	return false
+}
+
+func (f fileSystem) join(elem ...string) string {
+	if f.fsys == nil {
+		return filepath.Join(elem...)
+	}
+
+	return path.Join(elem...)
+}
+
+func (f fileSystem) dir(name string) string {
+	if f.fsys == nil {
+		return filepath.Dir(name)
+	}
+
+	return path.Dir(name)
+}
+
+// writeFile writes data to temporary file in the same directory, syncs it and renames it to the file
+func writeFile(name string, data []byte, perm os.FileMode) (err error) {
+	var tmp *os.File
+	tmp, err = os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if e := tmp.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return
+	}
+
+	err = os.Chmod(tmp.Name(), perm)
+	if err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// InitFS sets path to a file with configuration (JSON format) in the file system (e.g. embed.FS)
+// and set refresh data from its like Init; includes and Path values are resolved relative to the file directory in the file system
+func InitFS(fsys fs.FS, cfgPath string, options ...InitOption) (err error) {
+	return initFile(fileSystem{fsys: fsys}, cfgPath, options)
+}
+
+// Load reads configuration from the reader and sets it without refresh,
+// includes and Path values are resolved relative to the working directory
+func Load(r io.Reader, format Format) (err error) {
+	var bs []byte
+	bs, err = io.ReadAll(r)
+	if err != nil {
+		err = fmt.Errorf("can't load config because: %s", err.Error())
+
+		return
+	}
+
+	return loadBytes(bs, format, "reader")
+}
+
+// LoadBytes sets configuration from the bytes without refresh like Load
+func LoadBytes(bs []byte, format Format) (err error) {
+	return loadBytes(bs, format, "bytes")
+}
+
+func initFile(fsys fileSystem, cfgPath string, options []InitOption) (err error) {
+	_, err = fsys.stat(cfgPath)
+	if err != nil {
+		err = fmt.Errorf("can't load config file %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	opts := newInitOptions(options)
+	if opts.interval <= 0 {
+		opts.interval = time.Second
+	}
+
+	atomic.StoreUint32(&withRefresh, 1)
+	atomic.StoreUint32(&stale, 0)
+	reloader.Store(loadJson)
+
+	fileSys.Store(fsys)
+	filePath.Store(cfgPath)
+	atomic.StoreUint32(&isLoaded, 0)
+
+	logger.Load().(logFn).info("Configuration is initialized", slog.String("file", cfgPath))
+
+	err = refreshJson()
+	if err != nil {
+		return
+	}
+
+	watch(opts, loadJson, slog.String("file", cfgPath))
+
+	return
+}
+
+func loadBytes(bs []byte, format Format, src string) (err error) {
+	defer notifyCommitted()
+
+	start := time.Now()
+
+	var obj Object
+	obj, err = decode(bs, format)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", src, err.Error())
+
+		return
+	}
+
+	inc := newIncluder(fileSystem{})
+
+	var v interface{}
+	v, err = inc.resolve(map[string]interface{}(obj), ".", "")
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", src, err.Error())
+
+		return
+	}
+
+	var sensitive, derived []string
+	obj, sensitive, derived, err = prepare(v.(map[string]interface{}))
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", src, err.Error())
+
+		return
+	}
+
+	var gen uint64
+	gen, err = commit(obj, src, sensitive, append(inc.directives, derived...))
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", src, err.Error())
+
+		return
+	}
+
+	atomic.StoreUint32(&withRefresh, 0)
+	atomic.StoreUint32(&stale, 0)
+	unwatch()
+
+	fileSys.Store(fileSystem{})
+	filePath.Store("")
+
+	logger.Load().(logFn).info(fmt.Sprintf("Configuration is loaded from %s", src),
+		slog.String("source", src),
+		slog.Uint64("generation", gen),
+		slog.Duration("duration", time.Since(start)))
+
+	return
+}
+
+// decode parses the bytes of the format
+func decode(bs []byte, format Format) (obj Object, err error) {
+	switch format {
+	case JSON, CompactJSON:
+		obj, err = decodeJson(bs)
+	default:
+		err = fmt.Errorf("format %s isn't supported", format)
+	}
+
+	return
+}
+
+// prepare decrypts values, resolves secrets and placeholders of the loaded object,
+// secrets are resolved before placeholders to be embedded into other values;
+// sensitive are paths of decrypted and secret values and of values that embed them,
+// derived are paths of values with resolved placeholders
+func prepare(obj Object) (res Object, sensitive, derived []string, err error) {
+	var decrypted, secrets, interpolated []string
+	res, decrypted, err = decryptPaths(obj)
+	if err != nil {
+		return
+	}
+
+	res, secrets, err = resolveSecretPaths(res)
+	if err != nil {
+		return
+	}
+
+	sensitive = append(decrypted, secrets...)
+
+	res, interpolated, derived, err = interpolatePaths(res, sensitive)
+	if err != nil {
+		return
+	}
+
+	sensitive = append(sensitive, interpolated...)
+
+	return
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestInitFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"configs/app.json": &fstest.MapFile{Data: []byte(`{"$include": "db.json", "static": "public", "dsn": "${db.host}"}`)},
+		"configs/db.json":  &fstest.MapFile{Data: []byte(`{"db": {"host": "localhost", "timeout": "5s"}}`)},
+	}
+
+	err := InitFS(fsys, "configs/app.json", NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if String("db.host") != "localhost" || String("dsn") != "localhost" {
+		t.Error("Configuration from file system contains unexpected values")
+	}
+	if Duration("db.timeout") != 5*time.Second {
+		t.Error("Duration from file system contains unexpected value")
+	}
+	if Path("static") != "configs/public" {
+		t.Errorf("Path isn't resolved relative to the file directory: %s", Path("static"))
+	}
+
+	checker, err := NewChecker([]byte(`{"db": {"host": {"required": true, "type": "string"}}}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := BeforeCommit(checker.Check)
+	defer h.Unregister()
+
+	fsys["configs/db.json"] = &fstest.MapFile{
+		Data:    []byte(`{"db": {"host": "db.local"}}`),
+		ModTime: time.Now().Add(time.Hour),
+	}
+
+	err = refreshJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if String("db.host") != "db.local" {
+		t.Error("Modified included file isn't reloaded from file system")
+	}
+
+	fsys["configs/db.json"] = &fstest.MapFile{
+		Data:    []byte(`{"db": {}}`),
+		ModTime: time.Now().Add(2 * time.Hour),
+	}
+
+	err = refreshJson()
+	if err == nil {
+		t.Error("Configuration from file system isn't checked")
+	}
+
+	err = InitFS(fsys, "configs/absent.json")
+	if err == nil {
+		t.Error("Absent file in file system doesn't return error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	defer InitAsStruct(Object{})
+
+	err := Load(strings.NewReader(`{"name": "reader", "dir": "data", "port": 8080}`), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if String("name") != "reader" || Int64("port") != 8080 || Path("dir") != "data" {
+		t.Error("Configuration from reader contains unexpected values")
+	}
+
+	err = Reload()
+	if err == nil {
+		t.Error("Reload of configuration from reader doesn't return error")
+	}
+
+	err = LoadBytes([]byte(`{"name": "bytes"}`), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if String("name") != "bytes" {
+		t.Error("Configuration from bytes contains unexpected values")
+	}
+
+	err = LoadBytes([]byte(`{"name":`), JSON)
+	if err == nil {
+		t.Error("Broken configuration doesn't return error")
+	}
+	err = LoadBytes([]byte(`name: yaml`), Format("yaml"))
+	if err == nil {
+		t.Error("Unsupported format doesn't return error")
+	}
+	if String("name") != "bytes" {
+		t.Error("Failed load replaces configuration")
+	}
+}
@@ -1,12 +1,21 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// DateLayout is layout of values returned by Date
+const DateLayout = "2006-01-02"
+
 type (
 	Object map[string]interface{}
 
@@ -59,9 +68,16 @@ func (o Object) Interface(path string) (value interface{}, err error) {
 		}
 
 		if i < last {
-			obj = v.(map[string]interface{})
+			obj, ok = asMap(v)
+			if !ok {
+				err = &ValueNotExist{
+					message: fmt.Sprintf("path `%s` isn't exist", path),
+				}
+
+				return
+			}
 		} else {
-			value = v.(interface{})
+			value = v
 		}
 	}
 
@@ -106,7 +122,6 @@ func (o Object) IsString(path string) (ok bool) {
 func (o Object) Int32(path string) (i32 int32, err error) {
 	var (
 		v   interface{}
-		f64 float64
 		i64 int64
 		str string
 		ok  bool
@@ -116,14 +131,7 @@ func (o Object) Int32(path string) (i32 int32, err error) {
 		return
 	}
 
-	f64, ok = v.(float64)
-	if ok {
-		i32 = int32(f64)
-
-		return
-	}
-
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -132,10 +140,10 @@ func (o Object) Int32(path string) (i32 int32, err error) {
 		return
 	}
 
-	i64, err = strconv.ParseInt(str, 10, 32)
+	i64, err = parseInt(str, 32)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't int32", path, Redact(path, str)),
 		}
 
 		return
@@ -157,7 +165,6 @@ func (o Object) IsInt32(path string) (ok bool) {
 func (o Object) UInt32(path string) (ui32 uint32, err error) {
 	var (
 		v    interface{}
-		f64  float64
 		ui64 uint64
 		str  string
 		ok   bool
@@ -167,14 +174,7 @@ func (o Object) UInt32(path string) (ui32 uint32, err error) {
 		return
 	}
 
-	f64, ok = v.(float64)
-	if ok {
-		ui32 = uint32(f64)
-
-		return
-	}
-
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -183,10 +183,10 @@ func (o Object) UInt32(path string) (ui32 uint32, err error) {
 		return
 	}
 
-	ui64, err = strconv.ParseUint(str, 10, 32)
+	ui64, err = parseUint(str, 32)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't uint32", path, Redact(path, str)),
 		}
 
 		return
@@ -208,7 +208,6 @@ func (o Object) IsUInt32(path string) (ok bool) {
 func (o Object) Int64(path string) (i64 int64, err error) {
 	var (
 		v   interface{}
-		f64 float64
 		str string
 		ok  bool
 	)
@@ -217,14 +216,7 @@ func (o Object) Int64(path string) (i64 int64, err error) {
 		return
 	}
 
-	f64, ok = v.(float64)
-	if ok {
-		i64 = int64(f64)
-
-		return
-	}
-
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -233,10 +225,10 @@ func (o Object) Int64(path string) (i64 int64, err error) {
 		return
 	}
 
-	i64, err = strconv.ParseInt(str, 10, 64)
+	i64, err = parseInt(str, 64)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't int64", path, Redact(path, str)),
 		}
 
 		return
@@ -256,7 +248,6 @@ func (o Object) IsInt64(path string) (ok bool) {
 func (o Object) UInt64(path string) (ui64 uint64, err error) {
 	var (
 		v   interface{}
-		f64 float64
 		str string
 		ok  bool
 	)
@@ -265,14 +256,7 @@ func (o Object) UInt64(path string) (ui64 uint64, err error) {
 		return
 	}
 
-	f64, ok = v.(float64)
-	if ok {
-		ui64 = uint64(f64)
-
-		return
-	}
-
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -281,10 +265,10 @@ func (o Object) UInt64(path string) (ui64 uint64, err error) {
 		return
 	}
 
-	ui64, err = strconv.ParseUint(str, 10, 64)
+	ui64, err = parseUint(str, 64)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't uint64", path, Redact(path, str)),
 		}
 
 		return
@@ -320,7 +304,7 @@ func (o Object) Float32(path string) (f32 float32, err error) {
 		return
 	}
 
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -332,7 +316,7 @@ func (o Object) Float32(path string) (f32 float32, err error) {
 	f64, err = strconv.ParseFloat(str, 32)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't float32", path, Redact(path, str)),
 		}
 
 		return
@@ -367,7 +351,7 @@ func (o Object) Float64(path string) (f64 float64, err error) {
 		return
 	}
 
-	str, ok = v.(string)
+	str, ok = numeric(v)
 	if !ok {
 		err = &ValueUnexpectedType{
 			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
@@ -379,7 +363,7 @@ func (o Object) Float64(path string) (f64 float64, err error) {
 	f64, err = strconv.ParseFloat(str, 64)
 	if err != nil {
 		err = &ValueUnexpectedType{
-			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't float64", path, Redact(path, str)),
 		}
 
 		return
@@ -542,18 +526,635 @@ func (o Object) IsMap(path string) (ok bool) {
 	return
 }
 
-func (o Object) Duration(path string) (dur time.Duration, err error) {
-	var i64 int64
-	i64, err = o.Int64(path)
+func (o Object) Duration(path string, unit ...time.Duration) (dur time.Duration, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
 	if err != nil {
 		return
 	}
 
-	dur = time.Duration(i64) * time.Second
+	str, ok = numeric(v)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	u := time.Duration(atomic.LoadInt64(&durationUnit))
+	if len(unit) > 0 {
+		u = unit[0]
+	}
+
+	dur, ok = parseDuration(str, u)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't duration", path, Redact(path, str)),
+		}
+
+		return
+	}
 
 	return
 }
 
 func (o Object) IsDuration(path string) (ok bool) {
-	return o.IsInt64(path)
+	_, err := o.Duration(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Bytes(path string) (size uint64, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = numeric(v)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	size, ok = parseBytes(str)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't byte size", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsBytes(path string) (ok bool) {
+	_, err := o.Bytes(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Time(path string, layout ...string) (t time.Time, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	if len(layout) == 0 {
+		layout = []string{time.RFC3339Nano}
+	}
+
+	for _, l := range layout {
+		t, err = time.Parse(l, str)
+		if err == nil {
+			return
+		}
+	}
+
+	err = &ValueUnexpectedType{
+		message: fmt.Sprintf("path `%s` contains value `%s` that isn't time", path, Redact(path, str)),
+	}
+
+	return
+}
+
+func (o Object) IsTime(path string, layout ...string) (ok bool) {
+	_, err := o.Time(path, layout...)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Date(path string) (date time.Time, err error) {
+	date, err = o.Time(path, DateLayout)
+	if _, ok := err.(*ValueUnexpectedType); ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value that isn't date", path),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsDate(path string) (ok bool) {
+	_, err := o.Date(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Location(path string) (loc *time.Location, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok || len(str) == 0 {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	loc, err = time.LoadLocation(str)
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't time zone", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsLocation(path string) (ok bool) {
+	_, err := o.Location(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) URL(path string) (u *url.URL, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	u, err = url.Parse(str)
+	if err == nil && len(u.Scheme) == 0 {
+		err = fmt.Errorf("url isn't absolute")
+	}
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't absolute url", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsURL(path string) (ok bool) {
+	_, err := o.URL(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) IP(path string) (ip net.IP, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	ip = net.ParseIP(str)
+	if ip == nil {
+		err = fmt.Errorf("can't parse ip address")
+	}
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't ip address", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsIP(path string) (ok bool) {
+	_, err := o.IP(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) IPNet(path string) (ipNet *net.IPNet, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	_, ipNet, err = net.ParseCIDR(str)
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't cidr", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsIPNet(path string) (ok bool) {
+	_, err := o.IPNet(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) HostPort(path string) (addr Address, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	addr, err = ParseAddress(str)
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't host:port", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsHostPort(path string) (ok bool) {
+	_, err := o.HostPort(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Regexp(path string) (re *regexp.Regexp, err error) {
+	var (
+		v   interface{}
+		str string
+		ok  bool
+	)
+	v, err = o.Interface(path)
+	if err != nil {
+		return
+	}
+
+	str, ok = v.(string)
+	if !ok {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains unexpected type of value", path),
+		}
+
+		return
+	}
+
+	re, err = regexp.Compile(str)
+	if err != nil {
+		err = &ValueUnexpectedType{
+			message: fmt.Sprintf("path `%s` contains value `%s` that isn't regular expression", path, Redact(path, str)),
+		}
+
+		return
+	}
+
+	return
+}
+
+func (o Object) IsRegexp(path string) (ok bool) {
+	_, err := o.Regexp(path)
+
+	ok = err == nil
+
+	return
+}
+
+// itemKey is the key under which each list element is wrapped to be read by scalar getters
+const itemKey = "item"
+
+// each calls fn for every element of the slice by path wrapped into an Object
+func (o Object) each(path string, fn func(item Object) error) (err error) {
+	var array []interface{}
+	array, err = o.Slice(path)
+	if err != nil {
+		return
+	}
+
+	for i, v := range array {
+		err = fn(Object{itemKey: v})
+		if err != nil {
+			err = &ValueUnexpectedType{
+				message: fmt.Sprintf("path `%s` contains unexpected type of value by index %d", path, i),
+			}
+
+			return
+		}
+	}
+
+	return
+}
+
+func (o Object) Int64List(path string) (list []int64, err error) {
+	err = o.each(path, func(item Object) (err error) {
+		var val int64
+		val, err = item.Int64(itemKey)
+		list = append(list, val)
+
+		return
+	})
+	if err != nil {
+		list = nil
+	}
+
+	return
+}
+
+func (o Object) IsInt64List(path string) (ok bool) {
+	_, err := o.Int64List(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) Float64List(path string) (list []float64, err error) {
+	err = o.each(path, func(item Object) (err error) {
+		var val float64
+		val, err = item.Float64(itemKey)
+		list = append(list, val)
+
+		return
+	})
+	if err != nil {
+		list = nil
+	}
+
+	return
+}
+
+func (o Object) IsFloat64List(path string) (ok bool) {
+	_, err := o.Float64List(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) BoolList(path string) (list []bool, err error) {
+	err = o.each(path, func(item Object) (err error) {
+		var val bool
+		val, err = item.Bool(itemKey)
+		list = append(list, val)
+
+		return
+	})
+	if err != nil {
+		list = nil
+	}
+
+	return
+}
+
+func (o Object) IsBoolList(path string) (ok bool) {
+	_, err := o.BoolList(path)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) DurationList(path string, unit ...time.Duration) (list []time.Duration, err error) {
+	err = o.each(path, func(item Object) (err error) {
+		var val time.Duration
+		val, err = item.Duration(itemKey, unit...)
+		list = append(list, val)
+
+		return
+	})
+	if err != nil {
+		list = nil
+	}
+
+	return
+}
+
+func (o Object) IsDurationList(path string, unit ...time.Duration) (ok bool) {
+	_, err := o.DurationList(path, unit...)
+
+	ok = err == nil
+
+	return
+}
+
+func (o Object) ObjectList(path string) (list []Object, err error) {
+	err = o.each(path, func(item Object) (err error) {
+		val, ok := asMap(item[itemKey])
+		if !ok {
+			return &ValueUnexpectedType{
+				message: fmt.Sprintf("path `%s` contains unexpected type of value", itemKey),
+			}
+		}
+
+		list = append(list, val)
+
+		return
+	})
+	if err != nil {
+		list = nil
+	}
+
+	return
+}
+
+func (o Object) IsObjectList(path string) (ok bool) {
+	_, err := o.ObjectList(path)
+
+	ok = err == nil
+
+	return
+}
+
+// numeric returns textual representation of a number decoded as json.Number, float64 or string
+func numeric(v interface{}) (str string, ok bool) {
+	switch n := v.(type) {
+	case json.Number:
+		str, ok = n.String(), true
+	case float64:
+		str, ok = strconv.FormatFloat(n, 'f', -1, 64), true
+	case string:
+		str, ok = n, true
+	}
+
+	return
+}
+
+// parseInt parses integer written in decimal, float or exponent form (e.g. `1e3` or `10.0`),
+// fractional and out of range values are rejected
+func parseInt(str string, bitSize int) (i64 int64, err error) {
+	i64, err = strconv.ParseInt(str, 10, bitSize)
+	if err == nil {
+		return
+	}
+
+	i, ok := integer(str)
+	if !ok || !i.IsInt64() {
+		return
+	}
+
+	max := int64(1)<<(bitSize-1) - 1
+	if i.Int64() > max || i.Int64() < -max-1 {
+		return
+	}
+
+	return i.Int64(), nil
+}
+
+// parseUint parses unsigned integer like parseInt
+func parseUint(str string, bitSize int) (ui64 uint64, err error) {
+	ui64, err = strconv.ParseUint(str, 10, bitSize)
+	if err == nil {
+		return
+	}
+
+	i, ok := integer(str)
+	if !ok || !i.IsUint64() {
+		return
+	}
+
+	if bitSize < 64 && i.Uint64() > uint64(1)<<bitSize-1 {
+		return
+	}
+
+	return i.Uint64(), nil
+}
+
+// integer returns exact value of the number if it's integral,
+// the magnitude is checked before exact parsing to avoid huge exponents
+func integer(str string) (i *big.Int, ok bool) {
+	f, _, err := big.ParseFloat(str, 10, 64, big.ToNearestEven)
+	if err != nil || f.IsInf() {
+		return
+	}
+
+	if f.Sign() == 0 {
+		return new(big.Int), true
+	}
+
+	exp := f.MantExp(nil)
+	if exp < 1 || exp > 65 {
+		return
+	}
+
+	r, ok := new(big.Rat).SetString(str)
+	if !ok || !r.IsInt() {
+		return nil, false
+	}
+
+	return r.Num(), true
+}
+
+func asMap(v interface{}) (m map[string]interface{}, ok bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m, ok = val, true
+	case Object:
+		m, ok = val, true
+	}
+
+	return
 }
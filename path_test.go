@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 )
@@ -269,3 +270,355 @@ func testParser(o Object, t *testing.T) {
 		t.Error("Method IsDuration returns unexpected result")
 	}
 }
+
+func TestJsonNumber(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"id": 18446744073709551615, "big": 9007199254740993,
+"wrap": 3e10, "negative": -1, "fractional": 1.5, "float": 0.1, "exp": 1e3, "integral": 10.0,
+"max": 1.8446744073709551615e19, "tiny": 1e-400, "huge": 1e400000}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	var ui64 uint64
+	ui64, err = obj.UInt64("id")
+	if err != nil {
+		t.Error(err)
+	}
+	if ui64 != 18446744073709551615 {
+		t.Error("Method UInt64 returns unexpected result")
+	}
+
+	var i64 int64
+	i64, err = obj.Int64("big")
+	if err != nil {
+		t.Error(err)
+	}
+	if i64 != 9007199254740993 {
+		t.Error("Method Int64 returns unexpected result")
+	}
+
+	if obj.IsInt32("wrap") {
+		t.Error("Method IsInt32 returns unexpected result")
+	}
+
+	_, err = obj.Int32("wrap")
+	if _, ok := err.(*ValueUnexpectedType); !ok {
+		t.Error("Method Int32 doesn't return error on overflow")
+	}
+
+	if obj.IsUInt32("negative") || obj.IsUInt64("negative") {
+		t.Error("Method IsUInt32 or IsUInt64 accepts negative value")
+	}
+
+	if obj.IsInt64("fractional") {
+		t.Error("Method IsInt64 accepts fractional value")
+	}
+
+	if obj.IsInt64("float") {
+		t.Error("Method IsInt64 accepts fractional value")
+	}
+
+	i64, err = obj.Int64("exp")
+	if err != nil || i64 != 1000 {
+		t.Error("Method Int64 rejects integral value in exponent form")
+	}
+
+	var i32 int32
+	i32, err = obj.Int32("integral")
+	if err != nil || i32 != 10 {
+		t.Error("Method Int32 rejects integral value in float form")
+	}
+
+	ui64, err = obj.UInt64("max")
+	if err != nil || ui64 != 18446744073709551615 {
+		t.Error("Method UInt64 rejects integral value in exponent form")
+	}
+
+	if obj.IsInt64("tiny") || obj.IsUInt64("huge") || !obj.IsUInt32("exp") {
+		t.Error("Methods return unexpected result for values in exponent form")
+	}
+
+	var f64 float64
+	f64, err = obj.Float64("float")
+	if err != nil {
+		t.Error(err)
+	}
+	if f64 != 0.1 {
+		t.Error("Method Float64 returns unexpected result")
+	}
+
+	var str string
+	str, err = obj.String("big")
+	if err != nil {
+		t.Error(err)
+	}
+	if str != "9007199254740993" {
+		t.Error("Method String returns unexpected result")
+	}
+
+	parsed, _ := Parse(map[string]interface{}{"wrap": float64(3e10), "fractional": 1.5})
+	if parsed.IsInt32("wrap") || parsed.IsInt64("fractional") {
+		t.Error("Methods accept float64 value that isn't exact integer")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"sec": 5, "go": "1m30s", "ms": "250ms", "iso": "PT5M",
+"iso_full": "P1DT1H0.5S", "iso_week": "P2W", "fractional": 1.5, "year": "P1Y", "bad": "soon"}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	tests := map[string]time.Duration{
+		"sec":        5 * time.Second,
+		"go":         90 * time.Second,
+		"ms":         250 * time.Millisecond,
+		"iso":        5 * time.Minute,
+		"iso_full":   25*time.Hour + 500*time.Millisecond,
+		"iso_week":   14 * 24 * time.Hour,
+		"fractional": 1500 * time.Millisecond,
+	}
+
+	var dur time.Duration
+	for path, expected := range tests {
+		dur, err = obj.Duration(path)
+		if err != nil {
+			t.Error(err)
+		}
+		if dur != expected {
+			t.Errorf("Method Duration returns unexpected result `%v` by path `%s`", dur, path)
+		}
+	}
+
+	dur, err = obj.Duration("sec", time.Millisecond)
+	if err != nil {
+		t.Error(err)
+	}
+	if dur != 5*time.Millisecond {
+		t.Error("Method Duration ignores the unit")
+	}
+
+	if obj.IsDuration("year") || obj.IsDuration("bad") {
+		t.Error("Method IsDuration returns unexpected result")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"bare": 512, "str": "512", "si": "64MB", "iec": "1GiB",
+"fractional": "1.5 KiB", "lower": "2kb", "bad": "64 parsecs", "negative": "-1KB", "half": "0.5B"}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	tests := map[string]uint64{
+		"bare":       512,
+		"str":        512,
+		"si":         64 * 1000 * 1000,
+		"iec":        1 << 30,
+		"fractional": 1536,
+		"lower":      2000,
+	}
+
+	var size uint64
+	for path, expected := range tests {
+		size, err = obj.Bytes(path)
+		if err != nil {
+			t.Error(err)
+		}
+		if size != expected {
+			t.Errorf("Method Bytes returns unexpected result `%v` by path `%s`", size, path)
+		}
+	}
+
+	if obj.IsBytes("bad") || obj.IsBytes("negative") || obj.IsBytes("half") {
+		t.Error("Method IsBytes returns unexpected result")
+	}
+}
+
+func TestTime(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"rfc": "2026-01-02T03:04:05Z", "custom": "02.01.2026 03:04",
+"date": "2026-01-02", "zone": "Europe/Berlin", "bad_zone": "Mars/Olympus", "number": 1}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var tm time.Time
+	tm, err = obj.Time("rfc")
+	if err != nil {
+		t.Error(err)
+	}
+	if !tm.Equal(expected) {
+		t.Error("Method Time returns unexpected result")
+	}
+
+	tm, err = obj.Time("custom", time.RFC3339, "02.01.2006 15:04")
+	if err != nil {
+		t.Error(err)
+	}
+	if !tm.Equal(expected.Truncate(time.Minute)) {
+		t.Error("Method Time returns unexpected result with custom layout")
+	}
+
+	if obj.IsTime("custom") || obj.IsTime("number") {
+		t.Error("Method IsTime returns unexpected result")
+	}
+
+	tm, err = obj.Date("date")
+	if err != nil {
+		t.Error(err)
+	}
+	if !tm.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Method Date returns unexpected result")
+	}
+
+	if obj.IsDate("rfc") {
+		t.Error("Method IsDate returns unexpected result")
+	}
+
+	_, err = obj.Date("absent")
+	if _, ok := err.(*ValueNotExist); !ok {
+		t.Error("Method Date returns unexpected error for absent path")
+	}
+
+	var loc *time.Location
+	loc, err = obj.Location("zone")
+	if err != nil {
+		t.Error(err)
+	}
+	if loc.String() != "Europe/Berlin" {
+		t.Error("Method Location returns unexpected result")
+	}
+
+	if obj.IsLocation("bad_zone") {
+		t.Error("Method IsLocation returns unexpected result")
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"url": "https://example.com:8443/api?x=1", "relative": "/api",
+"ip": "10.0.0.1", "ip6": "::1", "cidr": "10.0.0.0/8", "addr": "db.local:5432", "any": ":8080",
+"no_port": "db.local", "big_port": "db.local:70000", "re": "^[a-z]+$", "bad_re": "(", "number": 1}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	u, err := obj.URL("url")
+	if err != nil {
+		t.Error(err)
+	}
+	if u.Hostname() != "example.com" || u.Port() != "8443" {
+		t.Error("Method URL returns unexpected result")
+	}
+
+	if obj.IsURL("relative") || obj.IsURL("number") {
+		t.Error("Method IsURL returns unexpected result")
+	}
+
+	ip, err := obj.IP("ip")
+	if err != nil {
+		t.Error(err)
+	}
+	if !ip.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Error("Method IP returns unexpected result")
+	}
+
+	if !obj.IsIP("ip6") || obj.IsIP("cidr") {
+		t.Error("Method IsIP returns unexpected result")
+	}
+
+	ipNet, err := obj.IPNet("cidr")
+	if err != nil {
+		t.Error(err)
+	}
+	if !ipNet.Contains(ip) {
+		t.Error("Method IPNet returns unexpected result")
+	}
+
+	addr, err := obj.HostPort("addr")
+	if err != nil {
+		t.Error(err)
+	}
+	if addr.Host != "db.local" || addr.Port != 5432 || addr.String() != "db.local:5432" {
+		t.Error("Method HostPort returns unexpected result")
+	}
+
+	if !obj.IsHostPort("any") || obj.IsHostPort("no_port") || obj.IsHostPort("big_port") {
+		t.Error("Method IsHostPort returns unexpected result")
+	}
+
+	re, err := obj.Regexp("re")
+	if err != nil {
+		t.Error(err)
+	}
+	if !re.MatchString("text") {
+		t.Error("Method Regexp returns unexpected result")
+	}
+
+	if obj.IsRegexp("bad_re") {
+		t.Error("Method IsRegexp returns unexpected result")
+	}
+}
+
+func TestTypedLists(t *testing.T) {
+	obj, err := decodeJson([]byte(`{"ints": [1, "2", 3], "floats": [0.5, "1.5"], "flags": [true, "false"],
+"durs": [5, "1m", "PT1H"], "objects": [{"host": "a"}, {"host": "b"}], "mixed": [1, "two"], "str": "text"}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	ints, err := obj.Int64List("ints")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(ints) != 3 || ints[0] != 1 || ints[1] != 2 || ints[2] != 3 {
+		t.Error("Method Int64List returns unexpected result")
+	}
+
+	floats, err := obj.Float64List("floats")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(floats) != 2 || floats[0] != 0.5 || floats[1] != 1.5 {
+		t.Error("Method Float64List returns unexpected result")
+	}
+
+	flags, err := obj.BoolList("flags")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(flags) != 2 || !flags[0] || flags[1] {
+		t.Error("Method BoolList returns unexpected result")
+	}
+
+	durs, err := obj.DurationList("durs")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(durs) != 3 || durs[0] != 5*time.Second || durs[1] != time.Minute || durs[2] != time.Hour {
+		t.Error("Method DurationList returns unexpected result")
+	}
+
+	objects, err := obj.ObjectList("objects")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(objects) != 2 || objects[1]["host"] != "b" {
+		t.Error("Method ObjectList returns unexpected result")
+	}
+
+	objects, err = Object{"objects": []interface{}{Object{"host": "a"}}}.ObjectList("objects")
+	if err != nil || len(objects) != 1 || objects[0]["host"] != "a" {
+		t.Errorf("Method ObjectList doesn't accept Object elements: %v", err)
+	}
+
+	_, err = obj.Int64List("mixed")
+	if _, ok := err.(*ValueUnexpectedType); !ok {
+		t.Error("Method Int64List returns unexpected error for mixed list")
+	}
+
+	if obj.IsInt64List("str") || obj.IsObjectList("ints") {
+		t.Error("Methods accept value that isn't list")
+	}
+}
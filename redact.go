@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	pathpkg "path"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// Redacted replaces sensitive values in logs, dumps, diffs and error messages
+const Redacted = "[REDACTED]"
+
+var (
+	sensitivePatterns = &atomic.Value{}
+	sensitivePaths    = &atomic.Value{}
+)
+
+func init() {
+	sensitivePatterns.Store([]string{"*password*", "*passwd*", "*secret*", "*token*"})
+	sensitivePaths.Store(map[string]bool{})
+}
+
+// Sensitive marks paths matched by the patterns (e.g. `*password*` or `db.*`) as sensitive
+func Sensitive(patterns ...string) {
+	p := sensitivePatterns.Load().([]string)
+	p = append(append([]string{}, p...), patterns...)
+	sensitivePatterns.Store(p)
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Mark paths `%s` as sensitive", strings.Join(patterns, "`, `")))
+}
+
+// IsSensitive checks the path or one of its parents is marked as sensitive
+// by a pattern or is resolved from a secret or an encrypted value
+func IsSensitive(path string) bool {
+	return isSensitive(path, sensitivePaths.Load().(map[string]bool))
+}
+
+// isSensitive checks the path or one of its parents is matched by a pattern or is one of the paths
+func isSensitive(path string, paths map[string]bool) bool {
+	path = strings.ToLower(path)
+	patterns := sensitivePatterns.Load().([]string)
+
+	for p := path; ; {
+		if paths[p] {
+			return true
+		}
+
+		for _, pattern := range patterns {
+			ok, _ := pathpkg.Match(strings.ToLower(pattern), p)
+			if ok {
+				return true
+			}
+		}
+
+		i := strings.LastIndex(p, ".")
+		if i == -1 {
+			return false
+		}
+
+		p = p[:i]
+	}
+}
+
+// Redact returns Redacted if the path is sensitive, sensitive values inside maps
+// and slices of any type (e.g. []Object) are replaced too
+func Redact(path string, val interface{}) interface{} {
+	if IsSensitive(path) {
+		return Redacted
+	}
+
+	rv := reflect.ValueOf(val)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return val
+		}
+
+		array := make([]interface{}, rv.Len())
+		for i := range array {
+			array[i] = Redact(path, rv.Index(i).Interface())
+		}
+
+		return array
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return val
+		}
+
+		obj := make(map[string]interface{}, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			key := iter.Key().String()
+			obj[key] = Redact(joinPath(path, key), iter.Value().Interface())
+		}
+
+		return obj
+	}
+
+	return val
+}
+
+// Dump returns the current configuration as indented JSON with redacted sensitive values
+func Dump() (bs []byte, err error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+
+	err = encoder.Encode(Redact("", map[string]interface{}(cfg.Load().(Object))))
+	if err != nil {
+		return
+	}
+
+	bs = buf.Bytes()
+
+	return
+}
+
+func storeSensitivePaths(paths ...[]string) {
+	m := map[string]bool{}
+	for _, list := range paths {
+		for _, p := range list {
+			m[strings.ToLower(p)] = true
+		}
+	}
+
+	sensitivePaths.Store(m)
+}
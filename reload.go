@@ -0,0 +1,172 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// InitOption sets how changes of the config file are detected after Init
+type InitOption func(o *initOptions)
+
+type initOptions struct {
+	polling  bool
+	interval time.Duration
+	signals  []os.Signal
+	headers  http.Header
+	timeout  time.Duration
+	attempts int
+	backoff  time.Duration
+	tls      *tls.Config
+	client   *http.Client
+	cache    string
+}
+
+func newInitOptions(options []InitOption) (opts initOptions) {
+	opts = initOptions{
+		polling: true,
+		headers: http.Header{},
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return
+}
+
+// ReloadOnSignal disables periodical checking of the config file and reloads it
+// when the process receives one of the signals (SIGHUP by default)
+func ReloadOnSignal(signals ...os.Signal) InitOption {
+	return func(o *initOptions) {
+		if len(signals) == 0 {
+			signals = []os.Signal{syscall.SIGHUP}
+		}
+
+		o.polling = false
+		o.signals = append(o.signals, signals...)
+	}
+}
+
+// PollInterval sets interval of checking of the source for changes (every second for files and 30 seconds for URLs by default)
+func PollInterval(interval time.Duration) InitOption {
+	return func(o *initOptions) {
+		o.interval = interval
+	}
+}
+
+// NoPolling disables periodical checking of the config file, it's reloaded only by Reload
+func NoPolling() InitOption {
+	return func(o *initOptions) {
+		o.polling = false
+	}
+}
+
+var (
+	reloader  = &atomic.Value{}
+	stopWatch = func() {}
+	watchMx   = &sync.Mutex{}
+)
+
+func init() {
+	reloader.Store(loadJson)
+}
+
+// Reload loads the config file (or the source set by InitURL) regardless of its modification time
+// and returns error of the load or the error of rejection by commit hooks
+func Reload() (err error) {
+	if atomic.LoadUint32(&withRefresh) != 1 {
+		err = errors.New("configuration isn't initialized from file")
+
+		return
+	}
+
+	return reloader.Load().(func(force bool) error)(true)
+}
+
+// watch starts checking of the source by the options instead of the previous one
+func watch(opts initOptions, load func(force bool) error, attrs ...slog.Attr) {
+	reloader.Store(load)
+
+	watchMx.Lock()
+	defer watchMx.Unlock()
+
+	stopWatch()
+
+	var (
+		done   = make(chan struct{})
+		ticker *time.Ticker
+		ticks  <-chan time.Time
+		sigs   = make(chan os.Signal, 1)
+	)
+
+	if opts.polling {
+		ticker = time.NewTicker(opts.interval)
+		ticks = ticker.C
+	}
+
+	if len(opts.signals) > 0 {
+		signal.Notify(sigs, opts.signals...)
+
+		logger.Load().(logFn).debug(fmt.Sprintf("Reload configuration on signals %v", opts.signals))
+	}
+
+	stopWatch = func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+		signal.Stop(sigs)
+		close(done)
+	}
+
+	go func() {
+		var e error
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticks:
+				e = load(false)
+			case sig := <-sigs:
+				logger.Load().(logFn).info(fmt.Sprintf("Reload configuration on signal %v", sig),
+					slog.String("signal", sig.String()))
+
+				e = Reload()
+			}
+
+			if e != nil {
+				logger.Load().(logFn).error(e.Error(),
+					append(attrs, slog.String("error_type", fmt.Sprintf("%T", e)))...)
+			}
+		}
+	}()
+}
+
+// onUnwatch adds function called when checking of the current source is stopped
+func onUnwatch(fn func()) {
+	watchMx.Lock()
+	defer watchMx.Unlock()
+
+	stop := stopWatch
+	stopWatch = func() {
+		stop()
+		fn()
+	}
+}
+
+// unwatch stops checking of the config file
+func unwatch() {
+	watchMx.Lock()
+	defer watchMx.Unlock()
+
+	stopWatch()
+	stopWatch = func() {}
+}
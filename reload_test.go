@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 1}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath, NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 2}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 2 {
+		t.Error("Configuration isn't reloaded")
+	}
+
+	h := BeforeCommit(func(candidate Object) error {
+		return errors.New("rejected")
+	})
+	err = Reload()
+	h.Unregister()
+	if err == nil {
+		t.Error("Reload doesn't return error of commit hook")
+	}
+
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version":`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Reload()
+	if err == nil {
+		t.Error("Reload doesn't return error of broken file")
+	}
+	if Int64("version") != 2 {
+		t.Error("Broken file replaces configuration")
+	}
+
+	InitAsStruct(Object{})
+
+	err = Reload()
+	if err == nil {
+		t.Error("Reload of configuration set as struct doesn't return error")
+	}
+}
@@ -0,0 +1,57 @@
+//go:build unix
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadOnSignal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 1}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath, ReloadOnSignal(syscall.SIGUSR1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	err = ioutil.WriteFile(cfgPath, []byte(`{"version": 2}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := make(chan struct{}, 1)
+	h := Refresh(func() {
+		reloaded <- struct{}{}
+	})
+	defer h.Unregister()
+
+	err = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Configuration isn't reloaded on signal")
+	}
+	if Int64("version") != 2 {
+		t.Error("Configuration reloaded on signal contains unexpected value")
+	}
+}
@@ -0,0 +1,308 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Header adds the header to requests of the remote source
+func Header(key, value string) InitOption {
+	return func(o *initOptions) {
+		o.headers.Add(key, value)
+	}
+}
+
+// BasicAuth sets basic authorization of requests of the remote source
+func BasicAuth(user, password string) InitOption {
+	return func(o *initOptions) {
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(user, password)
+
+		o.headers.Set("Authorization", req.Header.Get("Authorization"))
+	}
+}
+
+// BearerToken sets bearer token authorization of requests of the remote source
+func BearerToken(token string) InitOption {
+	return func(o *initOptions) {
+		o.headers.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// RequestTimeout sets timeout of a request of the remote source (10 seconds by default)
+func RequestTimeout(timeout time.Duration) InitOption {
+	return func(o *initOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Retry sets number of attempts to fetch the remote source (3 by default)
+// and delay before the second attempt which is doubled before each next one (500 milliseconds by default)
+func Retry(attempts int, backoff time.Duration) InitOption {
+	return func(o *initOptions) {
+		o.attempts = attempts
+		o.backoff = backoff
+	}
+}
+
+// ClientTLS sets TLS configuration (e.g. client certificates and root CAs) of requests of the remote source
+func ClientTLS(config *tls.Config) InitOption {
+	return func(o *initOptions) {
+		o.tls = config
+	}
+}
+
+// HTTPClient sets client of requests of the remote source, RequestTimeout and ClientTLS are ignored with it
+func HTTPClient(client *http.Client) InitOption {
+	return func(o *initOptions) {
+		o.client = client
+	}
+}
+
+type remote struct {
+	url  string
+	opts initOptions
+
+	mx         sync.Mutex
+	validators validators
+	loaded     bool
+}
+
+// validators are ETag and Last-Modified of the accepted response
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// InitURL sets URL of configuration (JSON format) served by HTTP(S) and set refresh data from its every 30 seconds;
+// ETag and Last-Modified of responses are used to skip unchanged configuration,
+// on failure of the request the last accepted configuration stays active
+func InitURL(rawURL string, options ...InitOption) (err error) {
+	var u *url.URL
+	u, err = url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		err = fmt.Errorf("can't load config from %s because: it isn't HTTP(S) URL", rawURL)
+
+		return
+	}
+
+	opts := newInitOptions(options)
+	if opts.interval <= 0 {
+		opts.interval = 30 * time.Second
+	}
+	if opts.timeout <= 0 {
+		opts.timeout = 10 * time.Second
+	}
+	if opts.attempts <= 0 {
+		opts.attempts = 3
+	}
+	if opts.backoff <= 0 {
+		opts.backoff = 500 * time.Millisecond
+	}
+	if opts.client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if opts.tls != nil {
+			transport.TLSClientConfig = opts.tls
+		}
+
+		opts.client = &http.Client{
+			Timeout:   opts.timeout,
+			Transport: transport,
+		}
+	}
+
+	r := &remote{
+		url:  rawURL,
+		opts: opts,
+	}
+
+	logger.Load().(logFn).info("Configuration is initialized", slog.String("url", rawURL))
+
+	err = r.update(false)
+	if err != nil {
+		err = fallback(opts.cache, rawURL, err)
+		if err != nil {
+			return
+		}
+	}
+
+	atomic.StoreUint32(&withRefresh, 1)
+
+	fileSys.Store(fileSystem{})
+	filePath.Store("")
+
+	watch(opts, r.load, slog.String("url", rawURL))
+
+	return
+}
+
+// load fetches the configuration if it's modified or if the load is forced
+func (r *remote) load(force bool) (err error) {
+	if atomic.LoadUint32(&withRefresh) != 1 {
+		return
+	}
+
+	return r.update(force)
+}
+
+// update fetches and commits the configuration, validators of the response are kept only if it's accepted;
+// the load lock isn't held while the configuration is fetched, so slow requests don't block other loads and Save
+func (r *remote) update(force bool) (err error) {
+	defer notifyCommitted()
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	start := time.Now()
+
+	var (
+		bs       []byte
+		v        validators
+		modified bool
+	)
+	bs, v, modified, err = r.fetch(force)
+	if err != nil || !modified {
+		return
+	}
+
+	loadMx.Lock()
+	defer loadMx.Unlock()
+
+	var obj Object
+	obj, err = decode(bs, JSON)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+
+		return
+	}
+
+	var sensitive, derived []string
+	obj, sensitive, derived, err = prepare(obj)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+
+		return
+	}
+
+	var gen uint64
+	gen, err = commit(obj, r.url, sensitive, derived)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+
+		return
+	}
+
+	r.validators = v
+
+	accept(r.opts.cache, r.url, bs)
+
+	attrs := []slog.Attr{
+		slog.String("url", r.url),
+		slog.Uint64("generation", gen),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if !r.loaded {
+		logger.Load().(logFn).info("Configuration is loaded", attrs...)
+	} else {
+		logger.Load().(logFn).info("Configuration is reloaded", attrs...)
+	}
+
+	r.loaded = true
+
+	return
+}
+
+// fetch requests the configuration with retries, modified is false if the server responds that it isn't changed
+func (r *remote) fetch(force bool) (bs []byte, v validators, modified bool, err error) {
+	backoff := r.opts.backoff
+
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		bs, v, modified, retry, err = r.request(force)
+		if err == nil || !retry || attempt >= r.opts.attempts {
+			return
+		}
+
+		logger.Load().(logFn).warn(fmt.Sprintf("Retry to load config from %s in %v because: %s", r.url, backoff, err.Error()),
+			slog.String("url", r.url),
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff))
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// request makes single request, retry is true if the error is temporary
+func (r *remote) request(force bool) (bs []byte, v validators, modified, retry bool, err error) {
+	var req *http.Request
+	req, err = http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+
+		return
+	}
+
+	for key, values := range r.opts.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if !force {
+		if r.validators.etag != "" {
+			req.Header.Set("If-None-Match", r.validators.etag)
+		}
+		if r.validators.lastModified != "" {
+			req.Header.Set("If-Modified-Since", r.validators.lastModified)
+		}
+	}
+
+	var resp *http.Response
+	resp, err = r.opts.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+		retry = true
+
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		err = fmt.Errorf("can't load config from %s because: server responds %s", r.url, resp.Status)
+		retry = true
+
+		return
+	case resp.StatusCode != http.StatusOK:
+		err = fmt.Errorf("can't load config from %s because: server responds %s", r.url, resp.Status)
+
+		return
+	}
+
+	bs, err = io.ReadAll(resp.Body)
+	if err != nil {
+		err = fmt.Errorf("can't load config from %s because: %s", r.url, err.Error())
+		retry = true
+
+		return
+	}
+
+	modified = true
+
+	v = validators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	return
+}
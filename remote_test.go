@@ -0,0 +1,205 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInitURL(t *testing.T) {
+	var (
+		version  int64 = 1
+		requests int64
+		failures int64
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-Service") != "test" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if atomic.LoadInt64(&failures) > 0 {
+			atomic.AddInt64(&failures, -1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		etag := fmt.Sprintf(`"v%d"`, atomic.LoadInt64(&version))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"version": %d}`, atomic.LoadInt64(&version))
+	}))
+	defer server.Close()
+
+	err := InitURL(server.URL, NoPolling(), BearerToken("token"), Header("X-Service", "test"), Retry(3, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if Int64("version") != 1 || source.Load().(string) != server.URL {
+		t.Error("Configuration from URL contains unexpected values")
+	}
+
+	refresh := reloader.Load().(func(force bool) error)
+
+	atomic.StoreInt64(&requests, 0)
+	gen := atomic.LoadUint64(&generation)
+	err = refresh(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadUint64(&generation) != gen || atomic.LoadInt64(&requests) != 1 {
+		t.Error("Not modified configuration is reloaded")
+	}
+
+	atomic.StoreInt64(&version, 2)
+	atomic.StoreInt64(&failures, 2)
+	atomic.StoreInt64(&requests, 0)
+	err = refresh(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 2 || atomic.LoadInt64(&requests) != 3 {
+		t.Errorf("Configuration isn't reloaded with retries, requests: %d", atomic.LoadInt64(&requests))
+	}
+
+	atomic.StoreInt64(&version, 3)
+	atomic.StoreInt64(&failures, 3)
+	err = Reload()
+	if err == nil {
+		t.Error("Failed request doesn't return error")
+	}
+	if Int64("version") != 2 {
+		t.Error("Last good configuration isn't kept on failure")
+	}
+
+	atomic.StoreInt64(&failures, 0)
+	h := BeforeCommit(func(candidate Object) error {
+		return errors.New("rejected")
+	})
+	err = refresh(false)
+	h.Unregister()
+	if err == nil {
+		t.Error("Rejected configuration doesn't return error")
+	}
+
+	err = refresh(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 3 {
+		t.Error("Configuration rejected once isn't applied on the next poll")
+	}
+
+	err = InitURL(server.URL, NoPolling())
+	if err == nil {
+		t.Error("Unauthorized request doesn't return error")
+	}
+
+	atomic.StoreInt64(&version, 4)
+	err = Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Int64("version") != 4 {
+		t.Error("Failed initialization replaces the active source")
+	}
+
+	err = InitURL("ftp://localhost/config.json")
+	if err == nil {
+		t.Error("URL with unsupported scheme doesn't return error")
+	}
+}
+
+func TestInitURLWithTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"secure": true}`)
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	err := InitURL(server.URL, NoPolling(), ClientTLS(&tls.Config{RootCAs: pool}), RequestTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	if !Bool("secure") {
+		t.Error("Configuration from HTTPS URL contains unexpected values")
+	}
+}
+
+func TestInitURLSlowFetch(t *testing.T) {
+	var (
+		slow     uint32
+		requests = make(chan struct{}, 1)
+		release  = make(chan struct{})
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadUint32(&slow) == 1 {
+			requests <- struct{}{}
+			<-release
+		}
+
+		fmt.Fprint(w, `{"version": 1}`)
+	}))
+	defer server.Close()
+
+	err := InitURL(server.URL, NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	atomic.StoreUint32(&slow, 1)
+
+	reloaded := make(chan error, 1)
+	go func() {
+		reloaded <- Reload()
+	}()
+
+	<-requests
+
+	revisions := History()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Rollback(revisions[len(revisions)-1].Generation)
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Rollback is blocked by slow request of configuration")
+	}
+
+	close(release)
+
+	err = <-reloaded
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,392 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// derivedPaths are paths of values of the active configuration that differ from the source:
+// values with resolved placeholders and include directives
+var derivedPaths = &atomic.Value{}
+
+func init() {
+	derivedPaths.Store([]string{})
+}
+
+var (
+	bareKey     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	plainKey    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	reservedKey = regexp.MustCompile(`(?i)^(y|n|yes|no|on|off|true|false|null)$`)
+)
+
+// Save writes the active configuration to the file in the format through temporary file, fsync and rename;
+// permissions of the existing file are kept (0644 for a new file) and the write doesn't trigger reload of the watched file.
+// Configuration with values resolved from secret providers or encrypted values isn't saved to keep them out of plain text,
+// configuration with resolved placeholders or includes isn't saved because they would be lost.
+// The watched config file is saved only as JSON because other formats can't be loaded.
+func Save(cfgPath string, format Format) (err error) {
+	cfgPath, err = filepath.Abs(cfgPath)
+	if err != nil {
+		return
+	}
+
+	if format != JSON && format != CompactJSON && filePath.Load().(string) != "" {
+		if _, ok := modTimes.Load().(map[string]int64)[cfgPath]; ok {
+			err = fmt.Errorf("can't save config to %s because: the watched file can't be loaded as %s", cfgPath, format)
+
+			return
+		}
+	}
+
+	paths := make([]string, 0)
+	for path := range sensitivePaths.Load().(map[string]bool) {
+		paths = append(paths, path)
+	}
+	if len(paths) > 0 {
+		sort.Strings(paths)
+
+		err = fmt.Errorf("can't save config to %s because: value by path `%s` is resolved from secret or encrypted value", cfgPath, paths[0])
+
+		return
+	}
+
+	paths = append(paths, derivedPaths.Load().([]string)...)
+	if len(paths) > 0 {
+		sort.Strings(paths)
+
+		err = fmt.Errorf("can't save config to %s because: value by path `%s` is resolved from placeholder or include", cfgPath, paths[0])
+
+		return
+	}
+
+	var bs []byte
+	bs, err = encode(cfg.Load().(Object), format)
+	if err != nil {
+		err = fmt.Errorf("can't save config to %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	perm := fs.FileMode(0644)
+
+	info, e := os.Stat(cfgPath)
+	if e == nil {
+		perm = info.Mode().Perm()
+	}
+
+	err = writeFile(cfgPath, bs, perm)
+	if err != nil {
+		err = fmt.Errorf("can't save config to %s because: %s", cfgPath, err.Error())
+
+		return
+	}
+
+	modTimesMx.Lock()
+	defer modTimesMx.Unlock()
+
+	files := modTimes.Load().(map[string]int64)
+	if _, ok := files[cfgPath]; ok && fileSys.Load().(fileSystem).fsys == nil {
+		info, err = os.Stat(cfgPath)
+		if err != nil {
+			return
+		}
+
+		updated := make(map[string]int64, len(files))
+		for file, modTime := range files {
+			updated[file] = modTime
+		}
+		updated[cfgPath] = info.ModTime().UnixNano()
+
+		modTimes.Store(updated)
+	}
+
+	logger.Load().(logFn).info(fmt.Sprintf("Configuration is saved to %s", cfgPath),
+		slog.String("file", cfgPath),
+		slog.String("format", string(format)))
+
+	return
+}
+
+// encode serializes the object in the format
+func encode(obj Object, format Format) (bs []byte, err error) {
+	var v interface{}
+	v, err = normalize(obj)
+	if err != nil {
+		return
+	}
+
+	buf := &bytes.Buffer{}
+
+	switch format {
+	case JSON, CompactJSON:
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		if format == JSON {
+			encoder.SetIndent("", "    ")
+		}
+
+		err = encoder.Encode(v)
+	case YAML:
+		err = encodeYaml(buf, v, 0)
+	case TOML:
+		err = encodeToml(buf, v.(map[string]interface{}), nil)
+
+		buf = bytes.NewBuffer(bytes.TrimLeft(buf.Bytes(), "\n"))
+	default:
+		err = fmt.Errorf("format %s isn't supported", format)
+	}
+
+	bs = buf.Bytes()
+
+	return
+}
+
+// normalize converts values of the object to JSON types (maps, slices, strings, json.Number, bool and nil)
+func normalize(obj Object) (v interface{}, err error) {
+	var bs []byte
+	bs, err = json.Marshal(obj)
+	if err != nil {
+		return
+	}
+
+	var res Object
+	res, err = decodeJson(bs)
+	if err != nil {
+		return
+	}
+	if res == nil {
+		res = Object{}
+	}
+
+	return map[string]interface{}(res), nil
+}
+
+func sortedKeys(m map[string]interface{}) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return
+}
+
+// quote returns the string as JSON string which is also valid YAML double-quoted and TOML basic string
+func quote(str string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(str)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func scalar(v interface{}) string {
+	switch typed := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(typed)
+	case bool:
+		if typed {
+			return "true"
+		}
+
+		return "false"
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
+
+func encodeYaml(buf *bytes.Buffer, v interface{}, indent int) (err error) {
+	pad := strings.Repeat("  ", indent)
+
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			buf.WriteString(pad + "{}\n")
+
+			return
+		}
+
+		for _, key := range sortedKeys(typed) {
+			name := key
+			if !plainKey.MatchString(key) || reservedKey.MatchString(key) {
+				name = quote(key)
+			}
+
+			err = encodeYamlItem(buf, pad+name+":", typed[key], indent)
+			if err != nil {
+				return
+			}
+		}
+	case []interface{}:
+		if len(typed) == 0 {
+			buf.WriteString(pad + "[]\n")
+
+			return
+		}
+
+		for _, item := range typed {
+			err = encodeYamlItem(buf, pad+"-", item, indent)
+			if err != nil {
+				return
+			}
+		}
+	default:
+		buf.WriteString(pad + scalar(typed) + "\n")
+	}
+
+	return
+}
+
+func encodeYamlItem(buf *bytes.Buffer, prefix string, v interface{}, indent int) (err error) {
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			buf.WriteString(prefix + " {}\n")
+
+			return
+		}
+	case []interface{}:
+		if len(typed) == 0 {
+			buf.WriteString(prefix + " []\n")
+
+			return
+		}
+	default:
+		buf.WriteString(prefix + " " + scalar(typed) + "\n")
+
+		return
+	}
+
+	buf.WriteString(prefix + "\n")
+
+	return encodeYaml(buf, v, indent+1)
+}
+
+func tomlKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+
+	return quote(key)
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+
+	return strings.Join(keys, ".")
+}
+
+// isTables returns true if the value is non-empty array of maps
+func isTables(v interface{}) bool {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	for _, item := range list {
+		if _, ok = item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func encodeToml(buf *bytes.Buffer, m map[string]interface{}, path []string) (err error) {
+	keys := sortedKeys(m)
+
+	for _, key := range keys {
+		v := m[key]
+		if _, ok := v.(map[string]interface{}); ok || isTables(v) {
+			continue
+		}
+
+		var val string
+		val, err = tomlValue(v, append(path, key))
+		if err != nil {
+			return
+		}
+
+		buf.WriteString(tomlKey(key) + " = " + val + "\n")
+	}
+
+	for _, key := range keys {
+		subPath := append(append([]string{}, path...), key)
+
+		switch typed := m[key].(type) {
+		case map[string]interface{}:
+			buf.WriteString("\n[" + tomlPath(subPath) + "]\n")
+
+			err = encodeToml(buf, typed, subPath)
+		case []interface{}:
+			if !isTables(typed) {
+				continue
+			}
+
+			for _, item := range typed {
+				buf.WriteString("\n[[" + tomlPath(subPath) + "]]\n")
+
+				err = encodeToml(buf, item.(map[string]interface{}), subPath)
+				if err != nil {
+					return
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func tomlValue(v interface{}, path []string) (val string, err error) {
+	switch typed := v.(type) {
+	case nil:
+		err = fmt.Errorf("path `%s` contains null that isn't supported by TOML", strings.Join(path, "."))
+	case map[string]interface{}:
+		items := make([]string, 0, len(typed))
+		for _, key := range sortedKeys(typed) {
+			var item string
+			item, err = tomlValue(typed[key], append(path, key))
+			if err != nil {
+				return
+			}
+
+			items = append(items, tomlKey(key)+" = "+item)
+		}
+
+		val = "{" + strings.Join(items, ", ") + "}"
+	case []interface{}:
+		items := make([]string, 0, len(typed))
+		for _, element := range typed {
+			var item string
+			item, err = tomlValue(element, path)
+			if err != nil {
+				return
+			}
+
+			items = append(items, item)
+		}
+
+		val = "[" + strings.Join(items, ", ") + "]"
+	default:
+		val = scalar(typed)
+	}
+
+	return
+}
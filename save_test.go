@@ -0,0 +1,223 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{
+		"name": "app",
+		"db": {"port": 5432, "hosts": ["a", "b"]},
+		"servers": [{"name": "x"}, {"name": "y"}],
+		"key with space": "line\nbreak",
+		"on": true
+	}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath, NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	err = Save(cfgPath, JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Permissions of saved file aren't kept: %v", info.Mode().Perm())
+	}
+
+	gen := atomic.LoadUint64(&generation)
+	err = refreshJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadUint64(&generation) != gen {
+		t.Error("Saved file triggers reload")
+	}
+
+	err = Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if String("name") != "app" || Int64("db.port") != 5432 || String("key with space") != "line\nbreak" {
+		t.Error("Saved file contains unexpected values")
+	}
+
+	expected := map[Format]string{
+		CompactJSON: `{"db":{"hosts":["a","b"],"port":5432},"key with space":"line\nbreak","name":"app","on":true,"servers":[{"name":"x"},{"name":"y"}]}` + "\n",
+		YAML: `db:
+  hosts:
+    - "a"
+    - "b"
+  port: 5432
+"key with space": "line\nbreak"
+name: "app"
+"on": true
+servers:
+  -
+    name: "x"
+  -
+    name: "y"
+`,
+		TOML: `"key with space" = "line\nbreak"
+name = "app"
+on = true
+
+[db]
+hosts = ["a", "b"]
+port = 5432
+
+[[servers]]
+name = "x"
+
+[[servers]]
+name = "y"
+`,
+	}
+	for format, content := range expected {
+		path := filepath.Join(dir, "config."+strings.Replace(string(format), " ", "_", -1))
+
+		err = Save(path, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var bs []byte
+		bs, err = ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != content {
+			t.Errorf("Saved %s contains unexpected content:\n%s", format, bs)
+		}
+	}
+
+	err = Save(cfgPath, YAML)
+	if err == nil {
+		t.Error("Watched file is saved as YAML")
+	}
+
+	err = Save(filepath.Join(dir, "config.ini"), Format("ini"))
+	if err == nil {
+		t.Error("Unsupported format doesn't return error")
+	}
+
+	InitAsStruct(Object{"empty": nil})
+
+	err = Save(filepath.Join(dir, "null.toml"), TOML)
+	if err == nil {
+		t.Error("Null value is saved as TOML")
+	}
+
+	storeSensitivePaths([]string{"db.password"})
+	defer storeSensitivePaths()
+
+	err = Save(filepath.Join(dir, "secret.json"), JSON)
+	if err == nil {
+		t.Error("Configuration with resolved secret is saved")
+	}
+
+	InitAsStruct(Object{"name": "app"})
+
+	err = Save(filepath.Join(dir, "secret.json"), JSON)
+	if err != nil {
+		t.Errorf("Sensitive paths of previous configuration are kept: %v", err)
+	}
+
+	files := map[string]string{
+		"placeholder.json": `{"home": "${env:HOME:-/root}"}`,
+		"include.json":     `{"db": {"$include": "db.json"}}`,
+		"db.json":          `{"port": 5432}`,
+	}
+	for name, content := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"placeholder.json", "include.json"} {
+		err = Init(filepath.Join(dir, name), NoPolling())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = Save(filepath.Join(dir, name), JSON)
+		if err == nil {
+			t.Errorf("Configuration of %s is saved without its placeholders or includes", name)
+		}
+	}
+
+	InitAsStruct(Object{"name": "app"})
+
+	err = Save(filepath.Join(dir, "struct.json"), JSON)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSaveFromHook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfgPath := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(cfgPath, []byte(`{"name": "app"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Init(cfgPath, NoPolling())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer InitAsStruct(Object{})
+
+	h := AfterCommit(func(active Object) error {
+		return Save(filepath.Join(dir, "backup.json"), JSON)
+	})
+	defer h.Unregister()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Reload()
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Save from commit hook is blocked by reload")
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "backup.json"))
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	secretKey    = "$secret"
+	secretScheme = "secret://"
+)
+
+// SecretProvider resolves a secret reference (the part after `scheme:` or `secret://scheme/`)
+type SecretProvider interface {
+	Secret(ref string) (secret string, err error)
+}
+
+// FileSecretProvider reads a secret from a file by its path, trailing line breaks are trimmed
+type FileSecretProvider struct{}
+
+func (p FileSecretProvider) Secret(ref string) (secret string, err error) {
+	var bs []byte
+	bs, err = ioutil.ReadFile(ref)
+	if err != nil {
+		return
+	}
+
+	secret = strings.TrimRight(string(bs), "\r\n")
+
+	return
+}
+
+// EnvSecretProvider reads a secret from an environment variable
+type EnvSecretProvider struct{}
+
+func (p EnvSecretProvider) Secret(ref string) (secret string, err error) {
+	var ok bool
+	secret, ok = os.LookupEnv(ref)
+	if !ok {
+		err = fmt.Errorf("environment variable %s isn't set", ref)
+	}
+
+	return
+}
+
+// StubSecretProvider returns secrets from the map, it's intended for tests
+type StubSecretProvider map[string]string
+
+func (p StubSecretProvider) Secret(ref string) (secret string, err error) {
+	var ok bool
+	secret, ok = p[ref]
+	if !ok {
+		err = fmt.Errorf("secret %s isn't set", ref)
+	}
+
+	return
+}
+
+var secretProviders = &atomic.Value{}
+
+func init() {
+	secretProviders.Store(map[string]SecretProvider{
+		"file": FileSecretProvider{},
+		"env":  EnvSecretProvider{},
+	})
+}
+
+// RegisterSecretProvider sets provider for secret references with the scheme
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	providers := map[string]SecretProvider{}
+	for s, p := range secretProviders.Load().(map[string]SecretProvider) {
+		providers[s] = p
+	}
+	providers[scheme] = provider
+	secretProviders.Store(providers)
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Register secret provider `%s`", scheme))
+}
+
+// ResolveSecrets returns a copy of the object with resolved secret references:
+// {"$secret": "scheme:ref"} objects and "secret://scheme/ref" strings
+func ResolveSecrets(obj Object) (res Object, err error) {
+	res, _, err = resolveSecretPaths(obj)
+
+	return
+}
+
+// resolveSecretPaths resolves secret references and returns paths of resolved secrets
+func resolveSecretPaths(obj Object) (res Object, paths []string, err error) {
+	var v interface{}
+	v, err = resolveSecrets(map[string]interface{}(obj), "", &paths)
+	if err != nil {
+		return
+	}
+
+	res = v.(map[string]interface{})
+
+	return
+}
+
+func resolveSecrets(v interface{}, path string, paths *[]string) (val interface{}, err error) {
+	switch typed := v.(type) {
+	case string:
+		if !strings.HasPrefix(typed, secretScheme) {
+			return typed, nil
+		}
+
+		ref := strings.TrimPrefix(typed, secretScheme)
+
+		i := strings.Index(ref, "/")
+		if i == -1 {
+			err = &UnresolvedValue{
+				message: fmt.Sprintf("path `%s` contains wrong secret reference `%s`", path, typed),
+			}
+
+			return
+		}
+
+		*paths = append(*paths, path)
+
+		return secret(path, ref[:i], ref[i+1:])
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			array[i], err = resolveSecrets(item, path, paths)
+			if err != nil {
+				return
+			}
+		}
+
+		return array, nil
+	}
+
+	m, ok := asMap(v)
+	if !ok {
+		return v, nil
+	}
+
+	ref, ok := m[secretKey]
+	if ok && len(m) == 1 {
+		str, ok := ref.(string)
+		i := strings.Index(str, ":")
+		if !ok || i == -1 {
+			err = &UnresolvedValue{
+				message: fmt.Sprintf("path `%s` contains wrong secret reference `%v`", path, ref),
+			}
+
+			return
+		}
+
+		*paths = append(*paths, path)
+
+		return secret(path, str[:i], str[i+1:])
+	}
+
+	obj := make(map[string]interface{}, len(m))
+	for key, item := range m {
+		obj[key], err = resolveSecrets(item, joinPath(path, key), paths)
+		if err != nil {
+			return
+		}
+	}
+
+	return obj, nil
+}
+
+func secret(path, scheme, ref string) (val interface{}, err error) {
+	provider, ok := secretProviders.Load().(map[string]SecretProvider)[scheme]
+	if !ok {
+		err = &UnresolvedValue{
+			message: fmt.Sprintf("path `%s` contains secret with unknown provider `%s`", path, scheme),
+		}
+
+		return
+	}
+
+	val, err = provider.Secret(ref)
+	if err != nil {
+		err = &UnresolvedValue{
+			message: fmt.Sprintf("path `%s` contains unresolved secret of provider `%s`: %v", path, scheme, err),
+		}
+
+		return
+	}
+
+	return
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
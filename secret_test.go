@@ -0,0 +1,83 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "db_pass"), []byte("s3cr3t\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Setenv("CONFIG_TEST_TOKEN", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	RegisterSecretProvider("vault", StubSecretProvider{"db/creds#user": "admin"})
+
+	obj := Object{
+		"db": map[string]interface{}{
+			"password": map[string]interface{}{"$secret": "file:" + filepath.Join(dir, "db_pass")},
+			"user":     "secret://vault/db/creds#user",
+		},
+		"tokens": []interface{}{map[string]interface{}{"$secret": "env:CONFIG_TEST_TOKEN"}},
+		"plain":  "text",
+	}
+
+	obj, err = ResolveSecrets(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var str string
+	str, _ = obj.String("db.password")
+	if str != "s3cr3t" {
+		t.Error("File secret isn't resolved")
+	}
+
+	str, _ = obj.String("db.user")
+	if str != "admin" {
+		t.Error("Registered provider secret isn't resolved")
+	}
+
+	list, _ := obj.List("tokens")
+	if len(list) != 1 || list[0] != "token" {
+		t.Error("Env secret isn't resolved")
+	}
+
+	_, err = ResolveSecrets(Object{"key": "secret://unknown/path"})
+	if _, ok := err.(*UnresolvedValue); !ok {
+		t.Error("Unknown provider isn't reported")
+	}
+
+	_, err = ResolveSecrets(Object{"key": "secret://vault/absent"})
+	if _, ok := err.(*UnresolvedValue); !ok {
+		t.Error("Absent secret isn't reported")
+	}
+
+	obj, _, _, err = prepare(Object{
+		"db": map[string]interface{}{
+			"password": map[string]interface{}{"$secret": "env:CONFIG_TEST_TOKEN"},
+		},
+		"dsn": "admin:${db.password}@localhost",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str, _ = obj.String("dsn")
+	if str != "admin:token@localhost" {
+		t.Errorf("Secret isn't embedded by placeholder: %s", str)
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// WatchBuffer is capacity of channels returned by Watch
+const WatchBuffer = 64
+
+// ChangeEvent describes a change of the value by path between two generations of the configuration
+type ChangeEvent struct {
+	Path       string
+	Old        interface{}
+	New        interface{}
+	Generation uint64
+	Source     string
+}
+
+// Watch returns channel with changes of values by the paths or patterns (see OnChange),
+// without paths every top-level key is watched; the channel is closed when the context is done.
+// Sending never blocks reload: if the consumer is slow and the buffer (WatchBuffer) is full,
+// the oldest event is dropped to deliver the newest one and the drop is logged as warning.
+func Watch(ctx context.Context, paths ...string) <-chan ChangeEvent {
+	if len(paths) == 0 {
+		paths = []string{"*"}
+	}
+
+	var (
+		ch     = make(chan ChangeEvent, WatchBuffer)
+		mx     = &sync.Mutex{}
+		closed bool
+		ids    []uint64
+	)
+
+	send := func(event ChangeEvent) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case ch <- event:
+			return
+		default:
+		}
+
+		select {
+		case dropped := <-ch:
+			logger.Load().(logFn).warn(fmt.Sprintf("Change event by path `%s` is dropped because of slow consumer", dropped.Path),
+				slog.String("path", dropped.Path),
+				slog.Uint64("generation", dropped.Generation))
+		default:
+		}
+
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	for _, path := range paths {
+		ids = append(ids, subscribe(path, send))
+	}
+
+	logger.Load().(logFn).debug(fmt.Sprintf("Watch changes of %v", paths))
+
+	go func() {
+		<-ctx.Done()
+
+		for _, id := range ids {
+			unsubscribe(id)
+		}
+
+		mx.Lock()
+		closed = true
+		close(ch)
+		mx.Unlock()
+	}()
+
+	return ch
+}